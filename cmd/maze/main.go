@@ -5,8 +5,11 @@ import (
 
 	"github.com/es-debug/backend-academy-2024-go-template/internal/application/session"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/placement"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/renderers"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/dijkstra"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/config"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/file"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/uis"
@@ -23,16 +26,44 @@ func main() {
 	}
 
 	generator := generators.New(cfg.GeneratorType)
-	solver := solvers.New(cfg.SolverType)
+	if cfg.GeneratorType == "composite" && len(cfg.CompositeLayout) != 0 {
+		generator = generators.NewComposite(cfg.CompositeLayout)
+	}
+
+	if bounds := cfg.SolutionBounds; bounds != nil { // Лабиринты перегенерируются, пока длина решения не попадёт в границы.
+		generator = generators.NewBounded(generator, bounds.Start, bounds.End, bounds.MinLength, bounds.MaxLength)
+	}
+
+	edges := make(map[sutils.Edge]int, len(cfg.CostModel.Edges))
+	for _, edge := range cfg.CostModel.Edges {
+		edges[sutils.Edge{From: edge.From, To: edge.To}] = edge.Cost
+	}
+
+	costs, err := solvers.NewCostModel(cfg.CostModel.Types, cfg.CostModel.IsStartCharged(), edges)
+	if err != nil {
+		os.Exit(1)
+	}
 
-	renderer, err := renderers.New(cfg.RendererType)
+	solver := solvers.New(cfg.SolverType, costs)
+
+	renderer, err := renderers.New(cfg.RendererType, cfg.OuterWall)
 	if err != nil {
 		os.Exit(1)
 	}
 
 	ui := uis.New(cfg.UIType, renderer)
 
-	s := session.New(generator, solver, ui)
+	options := session.Options{
+		Animate:     cfg.Animate,
+		Placement:   placement.Mode(cfg.Placement),
+		MinDistance: cfg.MinDistance,
+	}
+
+	if cfg.Heatmap { // Карту расстояний строит Дейкстра, какой бы решатель ни был выбран.
+		options.Heatmap = dijkstra.NewSolver(costs)
+	}
+
+	s := session.New(generator, solver, ui, options)
 
 	err = s.Run()
 	if err != nil {
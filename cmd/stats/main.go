@@ -0,0 +1,213 @@
+package main
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"text/tabwriter"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/metrics"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/placement"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/config"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/file"
+)
+
+const pathToConfig = "./internal/infrastructure/files/config.json"
+
+var (
+	// ErrUnknownFormat возвращается для неизвестного формата вывода.
+	ErrUnknownFormat = errors.New("unknown output format")
+	// ErrIncompleteEndpoints возвращается, если задан только один из концов пути.
+	ErrIncompleteEndpoints = errors.New("start and end must be given together")
+	// ErrInvalidCoordinates возвращается для координат не в виде "x,y".
+	ErrInvalidCoordinates = errors.New("coordinates must be given as x,y")
+)
+
+func main() {
+	cfg := config.Config{}
+
+	err := file.LoadData(pathToConfig, &cfg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, fmt.Errorf("can`t load config: %w", err))
+		os.Exit(1)
+	}
+
+	err = run(os.Args[1:], cfg, os.Stdout)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// run генерирует лабиринт по аргументам командной строки args и настройкам cfg и выводит его характеристики в out
+// таблицей или в формате JSON.
+func run(args []string, cfg config.Config, out io.Writer) error {
+	flags := flag.NewFlagSet("stats", flag.ContinueOnError)
+	generatorType := flags.String("generator", cfg.GeneratorType, "generator type")
+	height := flags.Int("height", 30, "maze height")
+	width := flags.Int("width", 30, "maze width")
+	format := flags.String("format", "table", "output format: table or json")
+	start := flags.String("start", "", "start coordinates as x,y")
+	end := flags.String("end", "", "end coordinates as x,y")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return fmt.Errorf("can`t parse arguments: %w", err)
+	}
+
+	if *format != "table" && *format != "json" {
+		return fmt.Errorf("format %q: %w", *format, ErrUnknownFormat)
+	}
+
+	generator := generators.New(*generatorType)
+	if *generatorType == "composite" && len(cfg.CompositeLayout) != 0 {
+		generator = generators.NewComposite(cfg.CompositeLayout)
+	}
+
+	mz, err := generator.Generate(*height, *width)
+	if err != nil {
+		return fmt.Errorf("can`t generate maze: %w", err)
+	}
+
+	report, err := analyze(mz, *start, *end)
+	if err != nil {
+		return err
+	}
+
+	if *format == "table" {
+		return writeTable(out, *generatorType, report)
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(report)
+}
+
+// analyze возвращает характеристики mz и пути между концами start и end, заданными строками вида "x,y".
+// Если концы не заданы, ими становятся клетки у противоположных углов; если конец недостижим из начала,
+// характеристики пути не включаются в отчёт.
+func analyze(mz maze.Maze, start, end string) (metrics.Report, error) {
+	report := metrics.Analyze(mz)
+
+	from, to, err := endpoints(start, end)
+	if err != nil {
+		return metrics.Report{}, err
+	}
+
+	if from == nil {
+		corner, opposite, err := placement.Place(mz, placement.Corners, 0)
+		if err != nil {
+			return metrics.Report{}, fmt.Errorf("can`t place start and end: %w", err)
+		}
+
+		from, to = &corner, &opposite
+	}
+
+	solution, err := metrics.Solve(mz, *from, *to)
+
+	switch {
+	case errors.Is(err, sutils.ErrUnreachable): // Характеристики лабиринта полезны и без пути.
+	case err != nil:
+		return metrics.Report{}, fmt.Errorf("can`t solve maze: %w", err)
+	default:
+		report.Solution = &solution
+	}
+
+	return report, nil
+}
+
+// endpoints возвращает координаты концов пути, разобранные из строк вида "x,y"; если обе строки пусты,
+// оба конца равны nil, а если пуста только одна, возвращается ErrIncompleteEndpoints.
+func endpoints(start, end string) (from, to *cells.Coordinates, err error) {
+	if (start == "") != (end == "") {
+		return nil, nil, ErrIncompleteEndpoints
+	}
+
+	if start == "" {
+		return nil, nil, nil
+	}
+
+	parse := func(s string) (*cells.Coordinates, error) {
+		var coords cells.Coordinates
+
+		_, err := fmt.Sscanf(s, "%d,%d", &coords.X, &coords.Y)
+		if err != nil {
+			return nil, fmt.Errorf("can`t parse coordinates %q: %w", s, ErrInvalidCoordinates)
+		}
+
+		return &coords, nil
+	}
+
+	from, err = parse(start)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	to, err = parse(end)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return from, to, nil
+}
+
+// writeTable выводит report в out таблицей.
+func writeTable(out io.Writer, generatorType string, report metrics.Report) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(w, "Генератор\t%s\n", generatorType)
+	fmt.Fprintf(w, "Размер\t%dx%d\n", report.Width, report.Height)
+	fmt.Fprintf(w, "Проходы\t%d\n", report.Passages)
+	fmt.Fprintf(w, "Тупики\t%d\n", report.DeadEnds)
+
+	for _, degree := range sortedKeys(report.Junctions) {
+		fmt.Fprintf(w, "Развилки степени %d\t%d\n", degree, report.Junctions[degree])
+	}
+
+	for _, length := range sortedKeys(report.Corridors) {
+		fmt.Fprintf(w, "Коридоры длины %d\t%d\n", length, report.Corridors[length])
+	}
+
+	fmt.Fprintf(w, "Коэффициент реки\t%.2f\n", report.RiverFactor)
+	fmt.Fprintf(w, "Циклы\t%d\n", report.Cycles)
+
+	for _, name := range sortedKeys(report.Terrain) {
+		fmt.Fprintf(w, "Клетки типа %s\t%d\n", name, report.Terrain[name])
+	}
+
+	if report.Solution != nil {
+		fmt.Fprintf(w, "Путь\t(%d, %d) -> (%d, %d)\n",
+			report.Solution.Start.X, report.Solution.Start.Y, report.Solution.End.X, report.Solution.End.Y)
+		fmt.Fprintf(w, "Длина пути\t%d\n", report.Solution.Length)
+		fmt.Fprintf(w, "Повороты\t%d\n", report.Solution.Turns)
+	}
+
+	err := w.Flush()
+	if err != nil {
+		return fmt.Errorf("can`t write table: %w", err)
+	}
+
+	return nil
+}
+
+// sortedKeys возвращает ключи m по возрастанию.
+func sortedKeys[K cmp.Ordered, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
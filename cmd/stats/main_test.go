@@ -0,0 +1,211 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/metrics"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils/sutilstest"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	cfg := config.Config{GeneratorType: "prim"}
+
+	tests := []struct {
+		name        string
+		args        []string
+		check       func(t *testing.T, out string)
+		expectedErr error
+	}{
+		{
+			name: "json with corner endpoints",
+			args: []string{"-height", "5", "-width", "6", "-format", "json"},
+			check: func(t *testing.T, out string) {
+				t.Helper()
+
+				var report metrics.Report
+
+				require.NoError(t, json.Unmarshal([]byte(out), &report))
+				assert.Equal(t, 5, report.Height)
+				assert.Equal(t, 6, report.Width)
+				assert.Equal(t, 30, report.Passages)
+				require.NotNil(t, report.Solution)
+				assert.Equal(t, cells.Coordinates{X: 0, Y: 0}, report.Solution.Start)
+				assert.Equal(t, cells.Coordinates{X: 5, Y: 4}, report.Solution.End)
+			},
+		},
+		{
+			name: "json with given endpoints",
+			args: []string{"-height", "5", "-width", "6", "-format", "json", "-start", "1,1", "-end", "3,2"},
+			check: func(t *testing.T, out string) {
+				t.Helper()
+
+				var report metrics.Report
+
+				require.NoError(t, json.Unmarshal([]byte(out), &report))
+				require.NotNil(t, report.Solution)
+				assert.Equal(t, cells.Coordinates{X: 1, Y: 1}, report.Solution.Start)
+				assert.Equal(t, cells.Coordinates{X: 3, Y: 2}, report.Solution.End)
+			},
+		},
+		{
+			name: "table",
+			args: []string{"-height", "5", "-width", "6"},
+			check: func(t *testing.T, out string) {
+				t.Helper()
+				assert.Regexp(t, `Генератор\s+prim\n`, out)
+				assert.Regexp(t, `Размер\s+6x5\n`, out)
+				assert.Regexp(t, `Путь\s+\(0, 0\) -> \(5, 4\)\n`, out)
+			},
+		},
+		{
+			name:        "unknown format",
+			args:        []string{"-height", "5", "-width", "6", "-format", "xml"},
+			expectedErr: ErrUnknownFormat,
+		},
+		{
+			name:        "only start is given",
+			args:        []string{"-height", "5", "-width", "6", "-start", "3,4"},
+			expectedErr: ErrIncompleteEndpoints,
+		},
+		{
+			name:        "end is out of bounds",
+			args:        []string{"-height", "5", "-width", "6", "-start", "0,0", "-end", "6,0"},
+			expectedErr: sutils.ErrOutOfBounds,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+
+			err := run(tt.args, cfg, &out)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			tt.check(t, out.String())
+		})
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	// Стена в столбце x = 1 делит лабиринт на две части.
+	split := sutilstest.NewGridMaze(2, 3, cells.Coordinates{X: 1, Y: 0}, cells.Coordinates{X: 1, Y: 1})
+
+	tests := []struct {
+		name             string
+		mz               maze.Maze
+		start            string
+		end              string
+		expectedSolution *metrics.Solution
+		expectedErr      error
+	}{
+		{
+			name:  "reachable end",
+			mz:    split,
+			start: "0,0",
+			end:   "0,1",
+			expectedSolution: &metrics.Solution{
+				Start: cells.Coordinates{X: 0, Y: 0}, End: cells.Coordinates{X: 0, Y: 1}, Length: 1,
+			},
+		},
+		{
+			name:  "unreachable end keeps maze metrics",
+			mz:    split,
+			start: "0,0",
+			end:   "2,1",
+		},
+		{
+			name:        "end in wall",
+			mz:          split,
+			start:       "0,0",
+			end:         "1,0",
+			expectedErr: sutils.ErrMaskedCell,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := analyze(tt.mz, tt.start, tt.end)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, 4, report.Passages)
+			assert.Equal(t, tt.expectedSolution, report.Solution)
+		})
+	}
+}
+
+func TestEndpoints(t *testing.T) {
+	tests := []struct {
+		name         string
+		start        string
+		end          string
+		expectedFrom *cells.Coordinates
+		expectedTo   *cells.Coordinates
+		expectedErr  error
+	}{
+		{
+			name: "no endpoints",
+		},
+		{
+			name:         "both endpoints",
+			start:        "1,2",
+			end:          "3,4",
+			expectedFrom: &cells.Coordinates{X: 1, Y: 2},
+			expectedTo:   &cells.Coordinates{X: 3, Y: 4},
+		},
+		{
+			name:        "only start",
+			start:       "1,2",
+			expectedErr: ErrIncompleteEndpoints,
+		},
+		{
+			name:        "only end",
+			end:         "3,4",
+			expectedErr: ErrIncompleteEndpoints,
+		},
+		{
+			name:        "malformed start",
+			start:       "1;2",
+			end:         "3,4",
+			expectedErr: ErrInvalidCoordinates,
+		},
+		{
+			name:        "malformed end",
+			start:       "1,2",
+			end:         "x,4",
+			expectedErr: ErrInvalidCoordinates,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to, err := endpoints(tt.start, tt.end)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedFrom, from)
+			assert.Equal(t, tt.expectedTo, to)
+		})
+	}
+}
@@ -1,40 +1,109 @@
 package session
 
 import (
+	"errors"
 	"fmt"
+	"math/big"
 
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/placement"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/deadend"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/tremaux"
 )
 
+// ErrWaypointsUnsupported возвращается, если заданы промежуточные точки, а решатель не умеет их учитывать.
+var ErrWaypointsUnsupported = errors.New("solver doesn`t support waypoints")
+
 type generator interface {
 	Generate(height, width int) (maze.Maze, error) // Возвращает сгенерированный лабиринт.
 }
 
 type solver interface {
-	Solve(mz maze.Maze, start, end cells.Coordinates) []cells.Coordinates // Ищет путь от start до end.
+	Solve(mz maze.Maze, start, end cells.Coordinates) (sutils.Result, error) // Ищет путь от start до end.
+}
+
+type tracingSolver interface {
+	// Ищет путь от start до end, передавая tracer события поиска.
+	SolveTraced(mz maze.Maze, start, end cells.Coordinates, tracer sutils.Tracer) (sutils.Result, error)
+}
+
+type waypointSolver interface {
+	// Ищет путь от start до end через все waypoints.
+	SolveWaypoints(mz maze.Maze, start, end cells.Coordinates, waypoints []cells.Coordinates) (sutils.Result, error)
+}
+
+type alternativesSolver interface {
+	Paths(mz maze.Maze, start, end cells.Coordinates) ([]sutils.Result, error) // Ищет несколько самых дешёвых путей.
+	CountOptimal(mz maze.Maze, start, end cells.Coordinates) (*big.Int, error) // Считает равноценные кратчайшие пути.
+}
+
+// DistanceMapper строит карту расстояний для тепловой карты независимо от решателя сессии.
+type DistanceMapper interface {
+	// Возвращает оценки путей от source до всех достижимых клеток.
+	DistanceMap(mz maze.Maze, source cells.Coordinates) (map[cells.Coordinates]sutils.Cost, error)
+}
+
+type filler interface {
+	// Ищет путь от start до end заполнением тупиков и возвращает его вместе с заполнением.
+	SolveFilled(mz maze.Maze, start, end cells.Coordinates) (sutils.Result, deadend.Filling, error)
+}
+
+type walker interface {
+	// Ищет путь от start до end прогулкой агента по алгоритму Тремо и возвращает его вместе с прогулкой.
+	SolveWalk(mz maze.Maze, start, end cells.Coordinates) (sutils.Result, tremaux.Walk, error)
 }
 
 type userInterface interface {
-	AskMazeDimensions() (height, width int)                          // Спрашивает ширину и высоту.
-	AskCoordinates(height, width int) (start, end cells.Coordinates) // Спрашивает координаты start и end.
-	DisplayMaze(mz maze.Maze)                                        // Отображает лабиринт.
-	DisplayMazeWithPath(mz maze.Maze, path []cells.Coordinates)      // Отображает лабиринт и путь на нём.
+	AskMazeDimensions() (height, width int) // Спрашивает ширину и высоту.
+	// Спрашивает координаты start, end и, если withWaypoints = true, промежуточных точек waypoints.
+	AskCoordinates(height, width int, withWaypoints bool) (start, end cells.Coordinates, waypoints []cells.Coordinates)
+	DisplayMaze(mz maze.Maze)                                   // Отображает лабиринт.
+	DisplayMazeWithPath(mz maze.Maze, path []cells.Coordinates) // Отображает лабиринт и путь на нём.
+	DisplayResult(result sutils.Result)                         // Отображает статистику поиска пути.
+	DisplaySolveError(err error)                                // Сообщает, почему путь не найден.
+	DisplayTrace(mz maze.Maze, events []sutils.Event)           // Анимирует поиск пути по его событиям.
+	// Отображает лабиринт с альтернативными путями и количество равноценных кратчайших путей.
+	DisplayAlternatives(mz maze.Maze, alternatives []sutils.Result, optimalCount *big.Int)
+	DisplayHeatmap(mz maze.Maze, distances map[cells.Coordinates]sutils.Cost) // Отображает тепловую карту расстояний.
+	// Отображает лабиринт, заполненные клетки filled и оставшийся путь path.
+	DisplayFilled(mz maze.Maze, path []cells.Coordinates, filled map[cells.Coordinates]struct{})
+	// Отображает лабиринт, путь path и проходы, помеченные алгоритмом Тремо один и два раза.
+	DisplayMarks(mz maze.Maze, path []cells.Coordinates, marks map[tremaux.Passage]int)
+}
+
+// Options содержит необязательные настройки Session.
+type Options struct {
+	Animate bool           // Если true и решатель поддерживает трассировку, поиск пути анимируется.
+	Heatmap DistanceMapper // Если не nil, отображается тепловая карта расстояний от начала, построенная им.
+	// Способ расстановки начала и конца пути; если он пустой или placement.Manual, координаты спрашиваются.
+	Placement   placement.Mode
+	MinDistance int // Наименьшее количество шагов между началом и концом для способа placement.Distance.
+}
+
+// solution содержит найденный путь и данные решателя, которые отображаются вместе с ним.
+type solution struct {
+	result  sutils.Result
+	filling *deadend.Filling // Заполнение тупиков, если решатель их заполнял.
+	walk    *tremaux.Walk    // Прогулка агента с метками, если решатель - алгоритм Тремо.
 }
 
-// Session хранит генератор, решатель и пользовательский интерфейс.
+// Session хранит генератор, решатель, пользовательский интерфейс и настройки.
 type Session struct {
 	generator generator
 	solver    solver
 	ui        userInterface
+	options   Options
 }
 
 // New возвращает инициализированную структуру Session.
-func New(generator generator, solver solver, ui userInterface) *Session {
+func New(generator generator, solver solver, ui userInterface, options Options) *Session {
 	return &Session{
 		generator: generator,
 		solver:    solver,
 		ui:        ui,
+		options:   options,
 	}
 }
 
@@ -47,12 +116,120 @@ func (s *Session) Run() error {
 		return fmt.Errorf("can`t generate maze: %w", err)
 	}
 
-	start, end := s.ui.AskCoordinates(height, width) // Спрашиваем координаты начала и конца.
+	start, end, waypoints, err := s.endpoints(mz) // Получаем координаты начала, конца и промежуточных точек.
+	if err != nil {
+		return err
+	}
+
+	sol, err := s.solve(mz, start, end, waypoints) // Ищем путь между началом и концом.
+
+	s.ui.DisplayMaze(mz) // Отображаем лабиринта на пользовательском интерфейсе.
+
+	if err != nil { // Отсутствие пути - не ошибка сессии, а результат, о котором нужно сообщить.
+		s.ui.DisplaySolveError(err)
+		return nil
+	}
+
+	s.ui.DisplayMazeWithPath(mz, sol.result.Path) // Отображеем лабиринт и путь на пользовательском интерфейсе.
+	s.ui.DisplayResult(sol.result)                // Отображаем статистику поиска.
+
+	if sol.filling != nil { // Решатель заполнял тупики - показываем заполненные клетки.
+		s.ui.DisplayFilled(mz, sol.filling.Path, sol.filling.Filled)
+	}
+
+	if sol.walk != nil { // Решатель ставил метки на проходах - показываем их.
+		s.ui.DisplayMarks(mz, sol.walk.Path, sol.walk.Marks)
+	}
+
+	if s.options.Heatmap != nil {
+		distances, err := s.options.Heatmap.DistanceMap(mz, start)
+		if err != nil {
+			return fmt.Errorf("can`t build distance map: %w", err)
+		}
+
+		s.ui.DisplayHeatmap(mz, distances)
+	}
+
+	if as, ok := s.solver.(alternativesSolver); ok && len(waypoints) == 0 { // Решатель умеет искать альтернативы.
+		return s.displayAlternatives(as, mz, start, end)
+	}
+
+	return nil
+}
+
+// endpoints возвращает начало, конец и промежуточные точки пути в mz: спрашивает их у пользователя
+// или расставляет способом из настроек.
+func (s *Session) endpoints(mz maze.Maze) (start, end cells.Coordinates, waypoints []cells.Coordinates, err error) {
+	if s.options.Placement == "" || s.options.Placement == placement.Manual {
+		_, withWaypoints := s.solver.(waypointSolver) // Промежуточные точки спрашиваются, только если решатель их учитывает.
+		start, end, waypoints = s.ui.AskCoordinates(mz.Height, mz.Width, withWaypoints)
+		return start, end, waypoints, nil
+	}
+
+	start, end, err = placement.Place(mz, s.options.Placement, s.options.MinDistance)
+	if err != nil {
+		return cells.Coordinates{}, cells.Coordinates{}, nil, fmt.Errorf("can`t place start and end: %w", err)
+	}
+
+	return start, end, nil, nil
+}
+
+// displayAlternatives ищет альтернативные пути и равноценные кратчайшие пути и отображает их.
+func (s *Session) displayAlternatives(as alternativesSolver, mz maze.Maze, start, end cells.Coordinates) error {
+	alternatives, err := as.Paths(mz, start, end)
+	if err != nil {
+		return fmt.Errorf("can`t find alternative paths: %w", err)
+	}
 
-	path := s.solver.Solve(mz, start, end) // Ищем путь между началом и концом.
+	optimalCount, err := as.CountOptimal(mz, start, end)
+	if err != nil {
+		return fmt.Errorf("can`t count optimal paths: %w", err)
+	}
 
-	s.ui.DisplayMaze(mz)               // Отображаем лабиринта на пользовательском интерфейсе.
-	s.ui.DisplayMazeWithPath(mz, path) // Отображеем лабиринт и путь на пользовательском интерфейсе.
+	s.ui.DisplayAlternatives(mz, alternatives, optimalCount)
 
 	return nil
 }
+
+// solve ищет путь от start до end через waypoints; если включена анимация
+// и решатель поддерживает трассировку, поиск анимируется. Если решатель заполняет тупики или ставит метки
+// на проходах, вместе с путём возвращаются данные, по которым он найден.
+func (s *Session) solve(mz maze.Maze, start, end cells.Coordinates, waypoints []cells.Coordinates) (solution, error) {
+	if len(waypoints) != 0 {
+		ws, ok := s.solver.(waypointSolver)
+		if !ok {
+			return solution{}, ErrWaypointsUnsupported
+		}
+
+		result, err := ws.SolveWaypoints(mz, start, end, waypoints)
+
+		return solution{result: result}, err
+	}
+
+	switch solver := s.solver.(type) {
+	case filler:
+		result, filling, err := solver.SolveFilled(mz, start, end)
+
+		return solution{result: result, filling: &filling}, err
+	case walker:
+		result, walk, err := solver.SolveWalk(mz, start, end)
+
+		return solution{result: result, walk: &walk}, err
+	}
+
+	ts, ok := s.solver.(tracingSolver)
+	if !s.options.Animate || !ok {
+		result, err := s.solver.Solve(mz, start, end)
+
+		return solution{result: result}, err
+	}
+
+	recorder := sutils.Recorder{}
+
+	result, err := ts.SolveTraced(mz, start, end, &recorder)
+	if err == nil {
+		s.ui.DisplayTrace(mz, recorder.Events)
+	}
+
+	return solution{result: result}, err
+}
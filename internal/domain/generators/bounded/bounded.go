@@ -0,0 +1,110 @@
+package bounded
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/gutils"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils"
+)
+
+// MaxAttempts - максимальное количество попыток сгенерировать подходящий лабиринт.
+const MaxAttempts = 1000
+
+var (
+	// ErrInvalidBounds возвращается, если границы длины решения некорректны или недостижимы.
+	ErrInvalidBounds = errors.New("invalid solution length bounds")
+	// ErrAttemptsExceeded возвращается, если за MaxAttempts попыток не удалось получить подходящий лабиринт.
+	ErrAttemptsExceeded = errors.New("attempts to generate maze exceeded")
+)
+
+type generator interface {
+	Generate(height, width int) (maze.Maze, error) // Возвращает сгенерированный лабиринт.
+}
+
+type solver interface {
+	Solve(mz maze.Maze, start, end cells.Coordinates) (sutils.Result, error) // Ищет путь от start до end.
+}
+
+// Generator - структура генератора лабиринтов с ограниченной длиной решения.
+type Generator struct {
+	generator generator // Генератор, создающий лабиринты-кандидаты.
+	solver    solver    // Решатель, по которому определяется длина решения.
+	start     cells.Coordinates
+	end       cells.Coordinates
+	minLength int // Минимальная длина решения в клетках.
+	maxLength int // Максимальная длина решения в клетках.
+}
+
+// NewGenerator возвращает указатель на новый Generator, генерирующий с помощью g лабиринты,
+// в которых найденный s путь от start до end содержит от minLength до maxLength клеток.
+func NewGenerator(g generator, s solver, start, end cells.Coordinates, minLength, maxLength int) *Generator {
+	return &Generator{
+		generator: g,
+		solver:    s,
+		start:     start,
+		end:       end,
+		minLength: minLength,
+		maxLength: maxLength,
+	}
+}
+
+// Generate генерирует лабиринт заданной высоты и ширины с длиной решения в заданных границах.
+func (g *Generator) Generate(height, width int) (maze.Maze, error) {
+	// Генерация устроена как ограниченный цикл повторных попыток:
+	// лабиринт-кандидат генерируется, решается, и если длина решения попала в границы, он возвращается.
+	err := g.validate(height, width)
+	if err != nil {
+		return maze.Maze{}, err
+	}
+
+	for range MaxAttempts {
+		mz, err := g.generator.Generate(height, width)
+		if err != nil {
+			return maze.Maze{}, fmt.Errorf("can`t generate candidate maze: %w", err)
+		}
+
+		result, err := g.solver.Solve(mz, g.start, g.end)
+		if err != nil { // Например, в пещере начало может оказаться в стене - пробуем следующего кандидата.
+			continue
+		}
+
+		if length := len(result.Path); length >= g.minLength && length <= g.maxLength {
+			return mz, nil
+		}
+	}
+
+	return maze.Maze{}, fmt.Errorf("can`t generate maze with solution length in [%d, %d]: %w",
+		g.minLength, g.maxLength, ErrAttemptsExceeded)
+}
+
+// validate проверяет, что координаты лежат в лабиринте, а границы длины решения достижимы.
+func (g *Generator) validate(height, width int) error {
+	if !gutils.IsInside(g.start, height, width) || !gutils.IsInside(g.end, height, width) {
+		return fmt.Errorf("start or end is outside the maze: %w", ErrInvalidBounds)
+	}
+
+	if g.start == g.end { // Путь из клетки в неё же всегда состоит из одной клетки, ограничивать нечего.
+		return fmt.Errorf("start and end coincide: %w", ErrInvalidBounds)
+	}
+
+	// Путь не может быть короче манхэттенского расстояния между концами и длиннее количества клеток.
+	shortest := abs(g.start.X-g.end.X) + abs(g.start.Y-g.end.Y) + 1
+
+	if g.minLength > g.maxLength || g.maxLength < shortest || g.minLength > height*width {
+		return fmt.Errorf("length bounds [%d, %d] are unreachable: %w", g.minLength, g.maxLength, ErrInvalidBounds)
+	}
+
+	return nil
+}
+
+// abs возвращает модуль числа.
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+
+	return x
+}
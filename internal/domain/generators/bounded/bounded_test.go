@@ -0,0 +1,107 @@
+package bounded_test
+
+import (
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/bounded"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/prim"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/dijkstra"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoundedGeneratorGenerate(t *testing.T) {
+	type args struct {
+		start     cells.Coordinates
+		end       cells.Coordinates
+		minLength int
+		maxLength int
+	}
+
+	tests := []struct {
+		name        string
+		args        args
+		expectedErr error
+	}{
+		{
+			name: "length is exactly manhattan distance",
+			args: args{
+				start:     cells.Coordinates{X: 0, Y: 0},
+				end:       cells.Coordinates{X: 0, Y: 3},
+				minLength: 4,
+				maxLength: 4,
+			},
+		},
+		{
+			name: "length is in wide range",
+			args: args{
+				start:     cells.Coordinates{X: 0, Y: 0},
+				end:       cells.Coordinates{X: 7, Y: 7},
+				minLength: 15,
+				maxLength: 40,
+			},
+		},
+		{
+			name: "max length is shorter than manhattan distance",
+			args: args{
+				start:     cells.Coordinates{X: 0, Y: 0},
+				end:       cells.Coordinates{X: 7, Y: 7},
+				minLength: 1,
+				maxLength: 14,
+			},
+			expectedErr: bounded.ErrInvalidBounds,
+		},
+		{
+			name: "start is end",
+			args: args{
+				start:     cells.Coordinates{X: 3, Y: 3},
+				end:       cells.Coordinates{X: 3, Y: 3},
+				minLength: 2,
+				maxLength: 64,
+			},
+			expectedErr: bounded.ErrInvalidBounds,
+		},
+		{
+			name: "end is outside the maze",
+			args: args{
+				start:     cells.Coordinates{X: 0, Y: 0},
+				end:       cells.Coordinates{X: 8, Y: 0},
+				minLength: 1,
+				maxLength: 64,
+			},
+			expectedErr: bounded.ErrInvalidBounds,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := bounded.NewGenerator(
+				prim.NewGenerator(),
+				dijkstra.NewSolver(sutils.DefaultCosts()),
+				tt.args.start,
+				tt.args.end,
+				tt.args.minLength,
+				tt.args.maxLength,
+			)
+
+			mz, err := g.Generate(8, 8)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+
+			result, err := dijkstra.NewSolver(sutils.DefaultCosts()).Solve(mz, tt.args.start, tt.args.end)
+			require.NoError(t, err)
+
+			length := len(result.Path)
+
+			assert.GreaterOrEqual(t, length, tt.args.minLength)
+			assert.LessOrEqual(t, length, tt.args.maxLength)
+		})
+	}
+}
@@ -0,0 +1,266 @@
+package cave
+
+import (
+	"fmt"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/gutils"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+)
+
+const (
+	wallChance     = 45 // Вероятность (в процентах) того, что клетка изначально окажется стеной.
+	smoothingSteps = 5  // Количество итераций сглаживания клеточным автоматом.
+	birthLimit     = 5  // Проход становится стеной, если вокруг него не меньше birthLimit стен.
+	survivalLimit  = 4  // Стена остаётся стеной, если вокруг неё не меньше survivalLimit стен.
+)
+
+var (
+	mooreDx = []int{-1, -1, -1, 0, 0, 1, 1, 1} // Слайс сдвигов по x, ведущий к координатам окрестности Мура.
+	mooreDy = []int{-1, 0, 1, -1, 1, -1, 0, 1} // Слайс сдвигов по y, ведущий к координатам окрестности Мура.
+)
+
+// Generator - структура генератора пещер по клеточному автомату.
+type Generator struct {
+	open map[cells.Coordinates]bool // Словарь координаты - является ли клетка проходом.
+	mz   maze.Maze
+}
+
+// NewGenerator возвращает указатель на новый Generator.
+func NewGenerator() *Generator {
+	return &Generator{
+		open: make(map[cells.Coordinates]bool),
+	}
+}
+
+// Generate генерирует лабиринт-пещеру заданной высоты и ширины.
+func (g *Generator) Generate(height, width int) (maze.Maze, error) {
+	g.prepare(height, width)
+
+	err := g.cave()
+	if err != nil {
+		return maze.Maze{}, fmt.Errorf("can`t generate using cellular automaton: %w", err)
+	}
+
+	return g.mz, nil
+}
+
+// cave генерирует пещеру с помощью клеточного автомата.
+func (g *Generator) cave() error {
+	// Суть генерации пещеры (в текущей реализации):
+	//
+	// Алгоритм:
+	// 1) Каждая клетка случайно становится стеной с вероятностью wallChance или проходом.
+	// 2) Несколько раз применяется правило 4-5 клеточного автомата: проход становится стеной,
+	//    если в его окрестности Мура не меньше birthLimit стен, а стена остаётся стеной,
+	//    если в её окрестности не меньше survivalLimit стен (клетки за границей считаются стенами).
+	// 3) Находятся компоненты связности проходов, и каждая из них соединяется прорезанным коридором
+	//    с уже связанной частью пещеры.
+	// 4) Проходы получают случайный тип, а каждая пара смежных по стороне проходов - переход.
+	//
+	// Получаемый лабиринт связен, содержит открытые области и множество циклов.
+	err := g.fillRandomly()
+	if err != nil {
+		return fmt.Errorf("can`t fill cave randomly: %w", err)
+	}
+
+	for range smoothingSteps {
+		g.smooth()
+	}
+
+	err = g.connectRegions()
+	if err != nil {
+		return fmt.Errorf("can`t connect cave regions: %w", err)
+	}
+
+	err = g.buildMaze()
+	if err != nil {
+		return fmt.Errorf("can`t build maze from cave: %w", err)
+	}
+
+	return nil
+}
+
+// prepare подготавливает Generator для исполнения Generate.
+func (g *Generator) prepare(height, width int) {
+	g.mz = maze.New(height, width)
+
+	clear(g.open)
+}
+
+// fillRandomly случайно заполняет пещеру стенами и проходами.
+func (g *Generator) fillRandomly() error {
+	for coords := range g.mz.Cells {
+		number, err := gutils.GetRandomInt(100)
+		if err != nil {
+			return fmt.Errorf("can`t generate random number: %w", err)
+		}
+
+		g.open[coords] = number >= wallChance
+	}
+
+	return nil
+}
+
+// smooth применяет к пещере одну итерацию правила 4-5.
+func (g *Generator) smooth() {
+	next := make(map[cells.Coordinates]bool, len(g.open))
+
+	for coords, isOpen := range g.open {
+		walls := g.countWallsAround(coords)
+
+		if isOpen {
+			next[coords] = walls < birthLimit
+		} else {
+			next[coords] = walls < survivalLimit
+		}
+	}
+
+	g.open = next
+}
+
+// countWallsAround возвращает количество стен в окрестности Мура клетки.
+func (g *Generator) countWallsAround(coords cells.Coordinates) int {
+	walls := 0
+
+	for i := range mooreDx {
+		neighbour := cells.Coordinates{X: coords.X + mooreDx[i], Y: coords.Y + mooreDy[i]}
+
+		if !g.open[neighbour] { // Клетки за границей лабиринта отсутствуют в словаре и считаются стенами.
+			walls++
+		}
+	}
+
+	return walls
+}
+
+// connectRegions соединяет все компоненты связности проходов в одну.
+func (g *Generator) connectRegions() error {
+	connected := make(map[cells.Coordinates]struct{}) // Множество координат уже связанной части пещеры.
+
+	for coords, isOpen := range g.open {
+		if isOpen {
+			g.markRegion(coords, connected) // Связанной частью изначально становится любая компонента.
+			break
+		}
+	}
+
+	if len(connected) == 0 { // Если сглаживание не оставило проходов, открываем случайную клетку.
+		coords, err := gutils.GetRandomCoords(g.mz.Height, g.mz.Width)
+		if err != nil {
+			return fmt.Errorf("can`t get random coordinates: %w", err)
+		}
+
+		g.open[coords] = true
+		connected[coords] = struct{}{}
+	}
+
+	for {
+		corridor := g.findCorridor(connected)
+		if corridor == nil { // Все проходы уже связаны.
+			return nil
+		}
+
+		for _, coords := range corridor { // Прорезаем коридор.
+			g.open[coords] = true
+			connected[coords] = struct{}{}
+		}
+
+		g.markRegion(corridor[0], connected) // Присоединяем достигнутую компоненту.
+	}
+}
+
+// markRegion добавляет в region все проходы, связанные с start.
+func (g *Generator) markRegion(start cells.Coordinates, region map[cells.Coordinates]struct{}) {
+	queue := []cells.Coordinates{start}
+	region[start] = struct{}{}
+
+	for len(queue) != 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for i := range gutils.Dx {
+			next := cells.Coordinates{X: current.X + gutils.Dx[i], Y: current.Y + gutils.Dy[i]}
+
+			if _, ok := region[next]; !ok && g.open[next] {
+				region[next] = struct{}{}
+				queue = append(queue, next)
+			}
+		}
+	}
+}
+
+// findCorridor ищет поиском в ширину от связанной части пещеры ближайший к ней несвязанный проход
+// и возвращает координаты коридора до него, начиная с самого прохода; если такого нет, возвращает nil.
+func (g *Generator) findCorridor(connected map[cells.Coordinates]struct{}) []cells.Coordinates {
+	predecessors := make(map[cells.Coordinates]cells.Coordinates)
+	queue := make([]cells.Coordinates, 0, len(connected))
+
+	for coords := range connected {
+		predecessors[coords] = coords
+		queue = append(queue, coords)
+	}
+
+	for len(queue) != 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if _, ok := connected[current]; !ok && g.open[current] { // Достигнут несвязанный проход.
+			return restoreCorridor(current, predecessors, connected)
+		}
+
+		for i := range gutils.Dx {
+			next := cells.Coordinates{X: current.X + gutils.Dx[i], Y: current.Y + gutils.Dy[i]}
+
+			if _, ok := predecessors[next]; !ok && gutils.IsInside(next, g.mz.Height, g.mz.Width) {
+				predecessors[next] = current
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	return nil
+}
+
+// restoreCorridor восстанавливает по предшественникам коридор от end до связанной части пещеры.
+func restoreCorridor(
+	end cells.Coordinates,
+	predecessors map[cells.Coordinates]cells.Coordinates,
+	connected map[cells.Coordinates]struct{},
+) []cells.Coordinates {
+	var corridor []cells.Coordinates
+
+	for current := end; ; current = predecessors[current] {
+		if _, ok := connected[current]; ok {
+			return corridor
+		}
+
+		corridor = append(corridor, current)
+	}
+}
+
+// buildMaze переносит пещеру в лабиринт, назначая проходам типы и соединяя смежные проходы.
+func (g *Generator) buildMaze() error {
+	var err error
+
+	for coords, isOpen := range g.open {
+		if !isOpen {
+			continue
+		}
+
+		g.mz.Cells[coords].Type, err = gutils.GetRandomSignificantType() // Клетка по координатам получает тип.
+		if err != nil {
+			return fmt.Errorf("can`t get random significant type: %w", err)
+		}
+
+		for i := range gutils.Dx {
+			adjacentCoords := cells.Coordinates{X: coords.X + gutils.Dx[i], Y: coords.Y + gutils.Dy[i]}
+
+			if g.open[adjacentCoords] {
+				g.mz.Cells[coords].Transitions = append(g.mz.Cells[coords].Transitions, adjacentCoords)
+			}
+		}
+	}
+
+	return nil
+}
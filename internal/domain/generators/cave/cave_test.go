@@ -0,0 +1,88 @@
+package cave_test
+
+import (
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/cave"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/gutils"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/gutils/gutilstest"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCaveGeneratorGenerate(t *testing.T) {
+	type args struct {
+		height int
+		width  int
+	}
+
+	tests := []struct {
+		name string
+		args args
+	}{
+		{
+			name: "height & width: 1x1",
+			args: args{
+				height: 1,
+				width:  1,
+			},
+		},
+		{
+			name: "height & width: 2x3",
+			args: args{
+				height: 2,
+				width:  3,
+			},
+		},
+		{
+			name: "height & width: 64x64",
+			args: args{
+				height: 64,
+				width:  64,
+			},
+		},
+		{
+			name: "height & width: 128x256",
+			args: args{
+				height: 128,
+				width:  256,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := cave.NewGenerator()
+
+			mz, err := g.Generate(tt.args.height, tt.args.width)
+
+			assert.NoError(t, err)
+			assert.True(t, areAdjacentPassagesJoined(mz))
+			assert.True(t, gutilstest.IsPassageComponentOnlyOne(mz))
+		})
+	}
+}
+
+// areAdjacentPassagesJoined проверяет, что между каждой парой смежных проходов есть переход, а у стен переходов нет.
+func areAdjacentPassagesJoined(mz maze.Maze) bool {
+	for coords, cell := range mz.Cells {
+		expected := 0
+
+		if cell.Type != cells.Wall {
+			for i := range gutils.Dx {
+				adjacentCoords := cells.Coordinates{X: coords.X + gutils.Dx[i], Y: coords.Y + gutils.Dy[i]}
+
+				if adjacent, ok := mz.Cells[adjacentCoords]; ok && adjacent.Type != cells.Wall {
+					expected++
+				}
+			}
+		}
+
+		if len(cell.Transitions) != expected {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,311 @@
+package composite
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/gutils"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+)
+
+var (
+	// ErrInvalidLayout возвращается, если раскладка регионов пуста или не прямоугольна.
+	ErrInvalidLayout = errors.New("invalid regions layout")
+	// ErrDisconnectedRegions возвращается, если между регионами невозможно прорезать двери.
+	ErrDisconnectedRegions = errors.New("regions can`t be joined")
+)
+
+// RegionGenerator генерирует лабиринт для отдельного региона.
+type RegionGenerator interface {
+	Generate(height, width int) (maze.Maze, error)
+}
+
+// door описывает возможную дверь между смежными по стороне клетками разных регионов.
+type door struct {
+	from cells.Coordinates
+	to   cells.Coordinates
+}
+
+// regionPair - неупорядоченная пара номеров регионов.
+type regionPair struct {
+	first  int
+	second int
+}
+
+// Generator - структура составного генератора, заполняющего регионы разными алгоритмами.
+type Generator struct {
+	layout  [][]RegionGenerator       // Раскладка генераторов регионов по строкам и столбцам.
+	regions map[cells.Coordinates]int // Словарь координаты - номер региона, которому принадлежит клетка.
+	mz      maze.Maze
+}
+
+// NewGenerator возвращает указатель на новый Generator с раскладкой регионов layout.
+func NewGenerator(layout [][]RegionGenerator) *Generator {
+	return &Generator{
+		layout:  layout,
+		regions: make(map[cells.Coordinates]int),
+	}
+}
+
+// Generate генерирует лабиринт заданной высоты и ширины.
+func (g *Generator) Generate(height, width int) (maze.Maze, error) {
+	err := g.prepare(height, width)
+	if err != nil {
+		return maze.Maze{}, err
+	}
+
+	err = g.composite()
+	if err != nil {
+		return maze.Maze{}, fmt.Errorf("can`t generate composite maze: %w", err)
+	}
+
+	return g.mz, nil
+}
+
+// composite генерирует лабиринт, составленный из регионов.
+func (g *Generator) composite() error {
+	// Суть составной генерации:
+	//
+	// Алгоритм:
+	// 1) Лабиринт равномерно делится на прямоугольные регионы согласно раскладке.
+	// 2) Каждый регион заполняется своим генератором независимо от остальных.
+	// 3) Между каждой парой соседних регионов выбирается случайная дверь - по возможности пара смежных проходов.
+	// 4) По алгоритму Краскала в случайном порядке прорезаются двери, соединяющие ещё не связанные регионы;
+	//    если дверь упирается в стену, от неё прорезается коридор до ближайшего прохода региона.
+	//
+	// Прорезается минимальное остовное множество дверей, поэтому из идеальных регионов получается идеальный лабиринт.
+	err := g.fillRegions()
+	if err != nil {
+		return fmt.Errorf("can`t fill regions: %w", err)
+	}
+
+	err = g.joinRegions()
+	if err != nil {
+		return fmt.Errorf("can`t join regions: %w", err)
+	}
+
+	return nil
+}
+
+// prepare подготавливает Generator для исполнения Generate.
+func (g *Generator) prepare(height, width int) error {
+	if len(g.layout) == 0 || len(g.layout[0]) == 0 {
+		return fmt.Errorf("layout is empty: %w", ErrInvalidLayout)
+	}
+
+	for _, row := range g.layout {
+		if len(row) != len(g.layout[0]) {
+			return fmt.Errorf("layout isn`t rectangular: %w", ErrInvalidLayout)
+		}
+	}
+
+	g.mz = maze.New(height, width)
+
+	clear(g.regions)
+
+	return nil
+}
+
+// fillRegions генерирует лабиринт каждого региона и переносит его в общий лабиринт.
+func (g *Generator) fillRegions() error {
+	rows, columns := len(g.layout), len(g.layout[0])
+
+	for row := range rows {
+		top, bottom := row*g.mz.Height/rows, (row+1)*g.mz.Height/rows
+
+		for column := range columns {
+			left, right := column*g.mz.Width/columns, (column+1)*g.mz.Width/columns
+
+			if top == bottom || left == right { // Регион оказался пустым из-за малых размеров лабиринта.
+				continue
+			}
+
+			regionMaze, err := g.layout[row][column].Generate(bottom-top, right-left)
+			if err != nil {
+				return fmt.Errorf("can`t generate region %d:%d: %w", row, column, err)
+			}
+
+			g.placeRegion(regionMaze, cells.Coordinates{X: left, Y: top}, row*columns+column)
+		}
+	}
+
+	return nil
+}
+
+// placeRegion переносит лабиринт региона в общий лабиринт со сдвигом offset.
+func (g *Generator) placeRegion(regionMaze maze.Maze, offset cells.Coordinates, region int) {
+	shift := func(coords cells.Coordinates) cells.Coordinates {
+		return cells.Coordinates{X: coords.X + offset.X, Y: coords.Y + offset.Y}
+	}
+
+	for coords, cell := range regionMaze.Cells {
+		shifted := shift(coords)
+
+		g.mz.Cells[shifted].Type = cell.Type
+
+		for _, transition := range cell.Transitions {
+			g.mz.Cells[shifted].Transitions = append(g.mz.Cells[shifted].Transitions, shift(transition))
+		}
+
+		g.regions[shifted] = region
+	}
+}
+
+// joinRegions прорезает минимальное остовное множество дверей между регионами.
+func (g *Generator) joinRegions() error {
+	doors, err := g.chooseDoors()
+	if err != nil {
+		return fmt.Errorf("can`t choose doors: %w", err)
+	}
+
+	pairs := make([]regionPair, 0, len(doors))
+	for pair := range doors {
+		pairs = append(pairs, pair)
+	}
+
+	err = gutils.Shuffle(pairs)
+	if err != nil {
+		return fmt.Errorf("can`t shuffle region pairs: %w", err)
+	}
+
+	components := gutils.NewDisjointSet[int]()
+	unions := 0
+
+	for _, pair := range pairs {
+		if components.Union(pair.first, pair.second) { // Регионы ещё не были связаны - прорезаем дверь.
+			d := doors[pair]
+
+			for _, end := range []cells.Coordinates{d.from, d.to} {
+				err = g.carveToPassage(end)
+				if err != nil {
+					return fmt.Errorf("can`t open door: %w", err)
+				}
+			}
+
+			g.mz.Cells[d.from].Transitions = append(g.mz.Cells[d.from].Transitions, d.to)
+			g.mz.Cells[d.to].Transitions = append(g.mz.Cells[d.to].Transitions, d.from)
+
+			unions++
+		}
+	}
+
+	if unions != g.countRegions()-1 {
+		return ErrDisconnectedRegions
+	}
+
+	return nil
+}
+
+// chooseDoors возвращает для каждой пары соседних регионов случайную дверь.
+// Предпочтение отдаётся дверям между проходами; если таких нет, выбирается любая дверь на границе регионов.
+func (g *Generator) chooseDoors() (map[regionPair]door, error) {
+	passageDoors := make(map[regionPair][]door)
+	anyDoors := make(map[regionPair][]door)
+
+	for from, region := range g.regions {
+		// Рассматриваем только соседей справа и снизу, чтобы каждая дверь встретилась единожды.
+		for _, to := range []cells.Coordinates{{X: from.X + 1, Y: from.Y}, {X: from.X, Y: from.Y + 1}} {
+			adjacentRegion, ok := g.regions[to]
+			if !ok || adjacentRegion == region {
+				continue
+			}
+
+			pair := regionPair{first: min(region, adjacentRegion), second: max(region, adjacentRegion)}
+			anyDoors[pair] = append(anyDoors[pair], door{from: from, to: to})
+
+			if g.mz.Cells[from].Type != cells.Wall && g.mz.Cells[to].Type != cells.Wall {
+				passageDoors[pair] = append(passageDoors[pair], door{from: from, to: to})
+			}
+		}
+	}
+
+	doors := make(map[regionPair]door, len(anyDoors))
+
+	for pair, ds := range anyDoors {
+		if len(passageDoors[pair]) != 0 {
+			ds = passageDoors[pair]
+		}
+
+		number, err := gutils.GetRandomInt(len(ds))
+		if err != nil {
+			return nil, fmt.Errorf("can`t generate random number of door: %w", err)
+		}
+
+		doors[pair] = ds[number]
+	}
+
+	return doors, nil
+}
+
+// carveToPassage прорезает внутри региона коридор от стены start до ближайшего прохода того же региона.
+func (g *Generator) carveToPassage(start cells.Coordinates) error {
+	if g.mz.Cells[start].Type != cells.Wall {
+		return nil
+	}
+
+	predecessors := map[cells.Coordinates]cells.Coordinates{start: start}
+	queue := []cells.Coordinates{start}
+
+	for len(queue) != 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if g.mz.Cells[current].Type != cells.Wall { // Достигнут проход - прорезаем коридор до него.
+			for current != start {
+				previous := predecessors[current]
+
+				err := g.carve(previous, current)
+				if err != nil {
+					return fmt.Errorf("can`t carve corridor: %w", err)
+				}
+
+				current = previous
+			}
+
+			return nil
+		}
+
+		for i := range gutils.Dx {
+			next := cells.Coordinates{X: current.X + gutils.Dx[i], Y: current.Y + gutils.Dy[i]}
+
+			if _, ok := predecessors[next]; !ok && g.isInside(next) && g.regions[next] == g.regions[start] {
+				predecessors[next] = current
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	return ErrDisconnectedRegions // В регионе нет ни одного прохода.
+}
+
+// carve делает стену wall проходом и связывает её с клеткой passage.
+func (g *Generator) carve(wall, passage cells.Coordinates) error {
+	var err error
+
+	g.mz.Cells[wall].Type, err = gutils.GetRandomSignificantType() // Клетка по координатам получает тип.
+	if err != nil {
+		return fmt.Errorf("can`t get random significant type: %w", err)
+	}
+
+	g.mz.Cells[wall].Transitions = append(g.mz.Cells[wall].Transitions, passage)
+	g.mz.Cells[passage].Transitions = append(g.mz.Cells[passage].Transitions, wall)
+
+	return nil
+}
+
+// isInside возвращает true, если координаты находятся в пределах лабиринта, иначе false.
+func (g *Generator) isInside(coords cells.Coordinates) bool {
+	return gutils.IsInside(coords, g.mz.Height, g.mz.Width)
+}
+
+// countRegions возвращает количество непустых регионов.
+func (g *Generator) countRegions() int {
+	regions := make(map[int]struct{})
+
+	for _, region := range g.regions {
+		regions[region] = struct{}{}
+	}
+
+	return len(regions)
+}
@@ -0,0 +1,87 @@
+package composite_test
+
+import (
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/cave"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/composite"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/gutils/gutilstest"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/prim"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/wilson"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompositeGeneratorGenerate(t *testing.T) {
+	type args struct {
+		layout [][]composite.RegionGenerator
+		height int
+		width  int
+	}
+
+	tests := []struct {
+		name            string
+		args            args
+		expectedPerfect bool
+	}{
+		{
+			name: "quadrants of perfect generators",
+			args: args{
+				layout: [][]composite.RegionGenerator{
+					{prim.NewGenerator(), wilson.NewGenerator()},
+					{wilson.NewGenerator(), prim.NewGenerator()},
+				},
+				height: 16,
+				width:  16,
+			},
+			expectedPerfect: true,
+		},
+		{
+			name: "layout is larger than maze",
+			args: args{
+				layout: [][]composite.RegionGenerator{
+					{prim.NewGenerator(), wilson.NewGenerator(), prim.NewGenerator()},
+				},
+				height: 1,
+				width:  2,
+			},
+			expectedPerfect: true,
+		},
+		{
+			name: "layout with cave region",
+			args: args{
+				layout: [][]composite.RegionGenerator{
+					{prim.NewGenerator(), cave.NewGenerator()},
+					{cave.NewGenerator(), prim.NewGenerator()},
+				},
+				height: 40,
+				width:  60,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := composite.NewGenerator(tt.args.layout)
+
+			mz, err := g.Generate(tt.args.height, tt.args.width)
+
+			assert.NoError(t, err)
+			assert.True(t, gutilstest.IsPassageComponentOnlyOne(mz))
+
+			if tt.expectedPerfect {
+				assert.Equal(t, tt.args.height*tt.args.width-1, gutilstest.CountEdges(mz))
+			}
+		})
+	}
+}
+
+func TestCompositeGeneratorGenerateInvalidLayout(t *testing.T) {
+	g := composite.NewGenerator([][]composite.RegionGenerator{
+		{prim.NewGenerator(), prim.NewGenerator()},
+		{prim.NewGenerator()},
+	})
+
+	_, err := g.Generate(8, 8)
+
+	assert.ErrorIs(t, err, composite.ErrInvalidLayout)
+}
@@ -1,15 +1,33 @@
 package generators
 
 import (
+	"runtime"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/bounded"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/cave"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/composite"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/prim"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/tiled"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/wilson"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils"
 )
 
 type generator interface {
 	Generate(height, width int) (maze.Maze, error)
 }
 
+// defaultTileSize - размер плитки плиточного генератора по умолчанию.
+const defaultTileSize = 64
+
+// defaultCompositeLayout - раскладка составного генератора по умолчанию: квадранты с чередующимися алгоритмами.
+var defaultCompositeLayout = [][]string{
+	{"prim", "wilson"},
+	{"wilson", "prim"},
+}
+
 // New как фабрика возвращает конкретную реализацию generators по строке, обозначающей желаемую реализацию.
 func New(generatorType string) generator {
 	switch generatorType {
@@ -17,7 +35,45 @@ func New(generatorType string) generator {
 		return prim.NewGenerator()
 	case "wilson":
 		return wilson.NewGenerator()
+	case "cave":
+		return cave.NewGenerator()
+	case "composite":
+		return NewComposite(defaultCompositeLayout)
+	case "tiled":
+		return NewTiled("prim", defaultTileSize)
 	default:
 		return prim.NewGenerator()
 	}
 }
+
+// NewBounded возвращает генератор, создающий лабиринты генератором g так, чтобы кратчайший путь
+// от start до end по алгоритму Дейкстры содержал от minLength до maxLength клеток.
+func NewBounded(g generator, start, end cells.Coordinates, minLength, maxLength int) generator {
+	solver := solvers.New("dijkstra", sutils.DefaultCosts()) // Ограничение задано в клетках, а не в стоимости.
+
+	return bounded.NewGenerator(g, solver, start, end, minLength, maxLength)
+}
+
+// NewComposite возвращает составной генератор, заполняющий регионы раскладки layout
+// реализациями generators, обозначенными её строками.
+func NewComposite(layout [][]string) generator {
+	regionGenerators := make([][]composite.RegionGenerator, len(layout))
+
+	for row := range layout {
+		regionGenerators[row] = make([]composite.RegionGenerator, len(layout[row]))
+
+		for column, generatorType := range layout[row] {
+			regionGenerators[row][column] = New(generatorType)
+		}
+	}
+
+	return composite.NewGenerator(regionGenerators)
+}
+
+// NewTiled возвращает генератор, заполняющий плитки размера tileSize реализацией generatorType
+// параллельно на всех доступных ядрах; generatorType должен обозначать генератор идеальных лабиринтов.
+func NewTiled(generatorType string, tileSize int) generator {
+	return tiled.NewGenerator(tileSize, runtime.NumCPU(), func() tiled.TileGenerator {
+		return New(generatorType)
+	})
+}
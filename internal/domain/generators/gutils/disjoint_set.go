@@ -0,0 +1,34 @@
+package gutils
+
+// DisjointSet - система непересекающихся множеств с элементами типа T.
+type DisjointSet[T comparable] map[T]T
+
+// NewDisjointSet возвращает инициализированный DisjointSet, в котором каждый элемент изначально - отдельное множество.
+func NewDisjointSet[T comparable]() DisjointSet[T] {
+	return make(DisjointSet[T])
+}
+
+// Find возвращает представителя множества, которому принадлежит x.
+func (ds DisjointSet[T]) Find(x T) T {
+	parent, ok := ds[x]
+	if !ok || parent == x {
+		return x
+	}
+
+	root := ds.Find(parent)
+	ds[x] = root // Сжатие пути.
+
+	return root
+}
+
+// Union объединяет множества x и y, возвращая false, если они уже совпадали.
+func (ds DisjointSet[T]) Union(x, y T) bool {
+	rootX, rootY := ds.Find(x), ds.Find(y)
+	if rootX == rootY {
+		return false
+	}
+
+	ds[rootX] = rootY
+
+	return true
+}
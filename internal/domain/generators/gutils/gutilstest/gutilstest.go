@@ -0,0 +1,67 @@
+package gutilstest
+
+import (
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+)
+
+// CountEdges возвращает количество переходов лабиринта без учёта направления.
+func CountEdges(mz maze.Maze) int {
+	edges := 0
+
+	for _, cell := range mz.Cells {
+		edges += len(cell.Transitions)
+	}
+
+	return edges / 2
+}
+
+// IsComponentOnlyOne проверяет, что в лабиринте одна компонента связности.
+func IsComponentOnlyOne(mz maze.Maze) bool {
+	number := 0
+
+	visited := make(map[cells.Coordinates]struct{})
+
+	// Каждый поиск в глубину охватывает ровно одну компоненту связности.
+	for cell := range mz.Cells {
+		if _, ok := visited[cell]; !ok {
+			dfs(cell, mz, visited)
+
+			number++
+		}
+
+		if number > 1 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsPassageComponentOnlyOne проверяет, что все проходы лабиринта образуют одну компоненту связности.
+func IsPassageComponentOnlyOne(mz maze.Maze) bool {
+	number := 0
+
+	visited := make(map[cells.Coordinates]struct{})
+
+	for coords, cell := range mz.Cells {
+		if _, ok := visited[coords]; !ok && cell.Type != cells.Wall {
+			dfs(coords, mz, visited)
+
+			number++
+		}
+	}
+
+	return number == 1
+}
+
+// dfs отмечает пройденные вершины.
+func dfs(current cells.Coordinates, mz maze.Maze, visited map[cells.Coordinates]struct{}) {
+	visited[current] = struct{}{}
+
+	for _, next := range mz.Cells[current].Transitions {
+		if _, ok := visited[next]; !ok {
+			dfs(next, mz, visited)
+		}
+	}
+}
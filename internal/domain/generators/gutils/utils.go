@@ -99,3 +99,17 @@ func GetRandomInt(limit int) (int, error) {
 
 	return int(result.Int64()), nil
 }
+
+// Shuffle случайно перемешивает слайс по алгоритму Фишера-Йетса.
+func Shuffle[T any](s []T) error {
+	for i := len(s) - 1; i > 0; i-- {
+		j, err := GetRandomInt(i + 1)
+		if err != nil {
+			return fmt.Errorf("can`t generate random index: %w", err)
+		}
+
+		s[i], s[j] = s[j], s[i]
+	}
+
+	return nil
+}
@@ -3,9 +3,8 @@ package prim_test
 import (
 	"testing"
 
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/gutils/gutilstest"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/prim"
-	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
-	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -70,40 +69,7 @@ func TestPrimGeneratorGenerate(t *testing.T) {
 			mz, err := g.Generate(tt.args.height, tt.args.width)
 
 			assert.NoError(t, err)
-			assert.True(t, isComponentOnlyOne(mz))
+			assert.True(t, gutilstest.IsComponentOnlyOne(mz))
 		})
 	}
 }
-
-// isComponentOnlyOne проверяет, что в лабиринте одна компонента связности.
-func isComponentOnlyOne(mz maze.Maze) bool {
-	number := 0
-
-	visited := make(map[cells.Coordinates]struct{})
-
-	// Каждый поиск в глубину охватывает ровно одну компоненту связности.
-	for cell := range mz.Cells {
-		if _, ok := visited[cell]; !ok {
-			dfs(cell, mz, visited)
-
-			number++
-		}
-
-		if number > 1 {
-			return false
-		}
-	}
-
-	return true
-}
-
-// dfs отмечает пройденные вершины.
-func dfs(current cells.Coordinates, mz maze.Maze, visited map[cells.Coordinates]struct{}) {
-	visited[current] = struct{}{}
-
-	for _, next := range mz.Cells[current].Transitions {
-		if _, ok := visited[next]; !ok {
-			dfs(next, mz, visited)
-		}
-	}
-}
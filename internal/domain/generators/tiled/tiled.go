@@ -0,0 +1,258 @@
+package tiled
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/gutils"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+)
+
+// ErrInvalidParameters возвращается, если размер плитки или количество воркеров неположительны.
+var ErrInvalidParameters = errors.New("invalid tiled generator parameters")
+
+// TileGenerator генерирует идеальный лабиринт для отдельной плитки.
+type TileGenerator interface {
+	Generate(height, width int) (maze.Maze, error)
+}
+
+// tile описывает прямоугольную плитку лабиринта.
+type tile struct {
+	row    int
+	column int
+	top    int
+	left   int
+	height int
+	width  int
+}
+
+// door описывает дверь между смежными по стороне клетками соседних плиток.
+type door struct {
+	from cells.Coordinates
+	to   cells.Coordinates
+}
+
+// Generator - структура генератора, параллельно заполняющего плитки лабиринта.
+type Generator struct {
+	tileSize         int                  // Длина стороны плитки в клетках.
+	workers          int                  // Количество горутин, генерирующих плитки.
+	newTileGenerator func() TileGenerator // Фабрика генераторов плиток, у каждой горутины - свой генератор.
+	rows             int                  // Количество строк плиток.
+	columns          int                  // Количество столбцов плиток.
+	mz               maze.Maze
+}
+
+// NewGenerator возвращает указатель на новый Generator с плитками размера tileSize, заполняемыми в workers горутинах
+// генераторами, которые возвращает newTileGenerator; генераторы плиток должны создавать идеальные лабиринты.
+func NewGenerator(tileSize, workers int, newTileGenerator func() TileGenerator) *Generator {
+	return &Generator{
+		tileSize:         tileSize,
+		workers:          workers,
+		newTileGenerator: newTileGenerator,
+	}
+}
+
+// Generate генерирует идеальный лабиринт заданной высоты и ширины.
+func (g *Generator) Generate(height, width int) (maze.Maze, error) {
+	err := g.prepare(height, width)
+	if err != nil {
+		return maze.Maze{}, err
+	}
+
+	err = g.tiled()
+	if err != nil {
+		return maze.Maze{}, fmt.Errorf("can`t generate tiled maze: %w", err)
+	}
+
+	return g.mz, nil
+}
+
+// tiled генерирует лабиринт из плиток.
+func (g *Generator) tiled() error {
+	// Суть плиточной генерации:
+	//
+	// Алгоритм:
+	// 1) Лабиринт делится на квадратные плитки со стороной tileSize (крайние плитки могут быть меньше).
+	// 2) Плитки распределяются между workers горутинами, каждая из которых своим генератором
+	//    заполняет плитку идеальным лабиринтом и переносит его в общий лабиринт.
+	// 3) Между каждой парой соседних плиток выбирается случайная дверь на их общей границе.
+	// 4) По алгоритму Краскала в случайном порядке прорезаются двери, соединяющие ещё не связанные плитки.
+	//
+	// Двери образуют остовное дерево над плитками, поэтому из идеальных плиток получается идеальный лабиринт.
+	err := g.fillTiles()
+	if err != nil {
+		return fmt.Errorf("can`t fill tiles: %w", err)
+	}
+
+	err = g.joinTiles()
+	if err != nil {
+		return fmt.Errorf("can`t join tiles: %w", err)
+	}
+
+	return nil
+}
+
+// prepare подготавливает Generator для исполнения Generate.
+func (g *Generator) prepare(height, width int) error {
+	if g.tileSize <= 0 || g.workers <= 0 {
+		return fmt.Errorf("tile size %d, workers %d: %w", g.tileSize, g.workers, ErrInvalidParameters)
+	}
+
+	g.mz = maze.New(height, width)
+	g.rows = (height + g.tileSize - 1) / g.tileSize
+	g.columns = (width + g.tileSize - 1) / g.tileSize
+
+	return nil
+}
+
+// fillTiles параллельно заполняет все плитки лабиринта.
+func (g *Generator) fillTiles() error {
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+
+	tiles := make(chan tile)
+
+	for range g.workers {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			tileGenerator := g.newTileGenerator()
+
+			for t := range tiles {
+				mu.Lock()
+				failed := firstErr != nil
+				mu.Unlock()
+
+				if failed { // После первой ошибки оставшиеся плитки лишь вычитываются из канала.
+					continue
+				}
+
+				err := g.fillTile(tileGenerator, t)
+				if err != nil {
+					mu.Lock()
+					firstErr = err
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	for row := range g.rows {
+		for column := range g.columns {
+			tiles <- g.newTile(row, column)
+		}
+	}
+
+	close(tiles)
+	wg.Wait()
+
+	return firstErr
+}
+
+// newTile возвращает плитку, находящуюся в строке row и столбце column.
+func (g *Generator) newTile(row, column int) tile {
+	top, left := row*g.tileSize, column*g.tileSize
+
+	return tile{
+		row:    row,
+		column: column,
+		top:    top,
+		left:   left,
+		height: min(g.tileSize, g.mz.Height-top),
+		width:  min(g.tileSize, g.mz.Width-left),
+	}
+}
+
+// fillTile генерирует лабиринт плитки и переносит его в общий лабиринт.
+// Горутины лишь читают словарь клеток и изменяют клетки своих плиток, поэтому синхронизация не требуется.
+func (g *Generator) fillTile(tileGenerator TileGenerator, t tile) error {
+	tileMaze, err := tileGenerator.Generate(t.height, t.width)
+	if err != nil {
+		return fmt.Errorf("can`t generate tile %d:%d: %w", t.row, t.column, err)
+	}
+
+	shift := func(coords cells.Coordinates) cells.Coordinates {
+		return cells.Coordinates{X: coords.X + t.left, Y: coords.Y + t.top}
+	}
+
+	for coords, cell := range tileMaze.Cells {
+		shifted := g.mz.Cells[shift(coords)]
+
+		shifted.Type = cell.Type
+
+		for _, transition := range cell.Transitions {
+			shifted.Transitions = append(shifted.Transitions, shift(transition))
+		}
+	}
+
+	return nil
+}
+
+// joinTiles прорезает двери между плитками, образующие остовное дерево над ними.
+func (g *Generator) joinTiles() error {
+	doors, err := g.chooseDoors()
+	if err != nil {
+		return fmt.Errorf("can`t choose doors: %w", err)
+	}
+
+	err = gutils.Shuffle(doors)
+	if err != nil {
+		return fmt.Errorf("can`t shuffle doors: %w", err)
+	}
+
+	components := gutils.NewDisjointSet[int]()
+
+	for _, d := range doors {
+		if components.Union(g.tileNumber(d.from), g.tileNumber(d.to)) { // Плитки ещё не были связаны.
+			g.mz.Cells[d.from].Transitions = append(g.mz.Cells[d.from].Transitions, d.to)
+			g.mz.Cells[d.to].Transitions = append(g.mz.Cells[d.to].Transitions, d.from)
+		}
+	}
+
+	return nil
+}
+
+// chooseDoors возвращает по одной случайной двери между каждой парой соседних плиток.
+func (g *Generator) chooseDoors() ([]door, error) {
+	doors := make([]door, 0, 2*g.rows*g.columns)
+
+	for row := range g.rows {
+		for column := range g.columns {
+			t := g.newTile(row, column)
+
+			if column+1 < g.columns { // Дверь в правую плитку.
+				offset, err := gutils.GetRandomInt(t.height)
+				if err != nil {
+					return nil, fmt.Errorf("can`t generate random door offset: %w", err)
+				}
+
+				from := cells.Coordinates{X: t.left + t.width - 1, Y: t.top + offset}
+				doors = append(doors, door{from: from, to: cells.Coordinates{X: from.X + 1, Y: from.Y}})
+			}
+
+			if row+1 < g.rows { // Дверь в нижнюю плитку.
+				offset, err := gutils.GetRandomInt(t.width)
+				if err != nil {
+					return nil, fmt.Errorf("can`t generate random door offset: %w", err)
+				}
+
+				from := cells.Coordinates{X: t.left + offset, Y: t.top + t.height - 1}
+				doors = append(doors, door{from: from, to: cells.Coordinates{X: from.X, Y: from.Y + 1}})
+			}
+		}
+	}
+
+	return doors, nil
+}
+
+// tileNumber возвращает номер плитки, которой принадлежат координаты.
+func (g *Generator) tileNumber(coords cells.Coordinates) int {
+	return (coords.Y/g.tileSize)*g.columns + coords.X/g.tileSize
+}
@@ -0,0 +1,94 @@
+package tiled_test
+
+import (
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/gutils/gutilstest"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/prim"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/tiled"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTiledGeneratorGenerate(t *testing.T) {
+	type args struct {
+		tileSize int
+		workers  int
+		height   int
+		width    int
+	}
+
+	tests := []struct {
+		name string
+		args args
+	}{
+		{
+			name: "height & width: 1x1",
+			args: args{
+				tileSize: 8,
+				workers:  4,
+				height:   1,
+				width:    1,
+			},
+		},
+		{
+			name: "tile is larger than maze",
+			args: args{
+				tileSize: 64,
+				workers:  4,
+				height:   10,
+				width:    20,
+			},
+		},
+		{
+			name: "maze isn`t divisible into tiles",
+			args: args{
+				tileSize: 8,
+				workers:  4,
+				height:   30,
+				width:    53,
+			},
+		},
+		{
+			name: "single worker",
+			args: args{
+				tileSize: 16,
+				workers:  1,
+				height:   64,
+				width:    64,
+			},
+		},
+		{
+			name: "height & width: 512x512",
+			args: args{
+				tileSize: 32,
+				workers:  8,
+				height:   512,
+				width:    512,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := tiled.NewGenerator(tt.args.tileSize, tt.args.workers, func() tiled.TileGenerator {
+				return prim.NewGenerator()
+			})
+
+			mz, err := g.Generate(tt.args.height, tt.args.width)
+
+			assert.NoError(t, err)
+			assert.True(t, gutilstest.IsComponentOnlyOne(mz))
+			assert.Equal(t, tt.args.height*tt.args.width-1, gutilstest.CountEdges(mz))
+		})
+	}
+}
+
+func TestTiledGeneratorGenerateInvalidParameters(t *testing.T) {
+	g := tiled.NewGenerator(0, 1, func() tiled.TileGenerator {
+		return prim.NewGenerator()
+	})
+
+	_, err := g.Generate(8, 8)
+
+	assert.ErrorIs(t, err, tiled.ErrInvalidParameters)
+}
@@ -175,5 +175,7 @@ func (g *Generator) addWanderingToMaze() error {
 		delete(g.unvisited, coords) // Удаляем координаты из непосещённых.
 	}
 
+	clear(g.wandering) // Блуждание стало частью лабиринта, и его переходы не должны добавиться повторно.
+
 	return nil
 }
@@ -0,0 +1,76 @@
+package wilson_test
+
+import (
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/gutils/gutilstest"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/wilson"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWilsonGeneratorGenerate(t *testing.T) {
+	type args struct {
+		height int
+		width  int
+	}
+
+	tests := []struct {
+		name string
+		args args
+	}{
+		{
+			name: "height & width: 1x1",
+			args: args{
+				height: 1,
+				width:  1,
+			},
+		},
+		{
+			name: "height & width: 8x8",
+			args: args{
+				height: 8,
+				width:  8,
+			},
+		},
+		{
+			name: "height & width: 6x12",
+			args: args{
+				height: 6,
+				width:  12,
+			},
+		},
+	}
+
+	// Один генератор на все вызовы: переходы прошлых блужданий и лабиринтов не должны попадать в новые.
+	g := wilson.NewGenerator()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for range 5 {
+				mz, err := g.Generate(tt.args.height, tt.args.width)
+				require.NoError(t, err)
+
+				assert.True(t, areTransitionsUnique(mz))
+				assert.True(t, gutilstest.IsPassageComponentOnlyOne(mz))
+				assert.Equal(t, tt.args.height*tt.args.width-1, gutilstest.CountEdges(mz))
+			}
+		})
+	}
+}
+
+// areTransitionsUnique проверяет, что ни у одной клетки лабиринта нет повторяющихся переходов.
+func areTransitionsUnique(mz maze.Maze) bool {
+	for _, cell := range mz.Cells {
+		for i, next := range cell.Transitions {
+			for _, other := range cell.Transitions[:i] {
+				if next == other {
+					return false
+				}
+			}
+		}
+	}
+
+	return true
+}
@@ -25,3 +25,10 @@ const (
 
 // Types хранит информацию о доступных типах.
 var Types = []Type{Wall, LightedPass, Pass}
+
+// Names хранит для названия каждого доступного типа сам тип.
+var Names = map[string]Type{
+	"Wall":        Wall,
+	"LightedPass": LightedPass,
+	"Pass":        Pass,
+}
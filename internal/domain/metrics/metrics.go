@@ -0,0 +1,171 @@
+package metrics
+
+import (
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/placement"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/bfs"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/turns"
+)
+
+// Report содержит характеристики лабиринта, по которым можно сравнивать генераторы.
+type Report struct {
+	Height   int
+	Width    int
+	Passages int // Количество проходов.
+	DeadEnds int // Количество тупиков - проходов ровно с одним переходом.
+	// Количество развилок по степени - количеству переходов из прохода, не меньшему трёх.
+	Junctions map[int]int
+	// Гистограмма длин коридоров {длина: количество}. Коридор - цепочка переходов между соседними развилками
+	// или тупиками, все промежуточные клетки которой имеют ровно два перехода; длина - количество переходов.
+	Corridors map[int]int
+	// Коэффициент реки в этом пакете - средняя длина коридора в переходах, а не доля тупиков или иная мера
+	// "речистости" из литературы: чем он больше, тем дольше путь идёт без выбора до очередной развилки или тупика.
+	// Так, у лабиринтов prim с короткими коридорами и множеством тупиков он меньше, чем у wilson.
+	RiverFactor float64
+	Cycles      int            // Количество независимых циклов.
+	Terrain     map[string]int // Количество клеток каждого типа по названию типа.
+	Solution    *Solution      `json:",omitempty"` // Характеристики пути, если заданы его концы.
+}
+
+// Solution содержит характеристики кратчайшего пути между двумя клетками.
+type Solution struct {
+	Start  cells.Coordinates
+	End    cells.Coordinates
+	Length int // Количество переходов.
+	Turns  int // Количество смен направления.
+}
+
+// Analyze возвращает характеристики mz без характеристик пути.
+func Analyze(mz maze.Maze) Report {
+	report := Report{
+		Height:    mz.Height,
+		Width:     mz.Width,
+		Junctions: make(map[int]int),
+		Corridors: make(map[int]int),
+		Terrain:   make(map[string]int),
+	}
+
+	names := make(map[cells.Type]string, len(cells.Names))
+	for name, t := range cells.Names {
+		names[t] = name
+	}
+
+	edges := 0
+
+	for _, cell := range mz.Cells {
+		report.Terrain[names[cell.Type]]++
+
+		if cell.Type == cells.Wall {
+			continue
+		}
+
+		report.Passages++
+		edges += len(cell.Transitions)
+
+		switch degree := len(cell.Transitions); {
+		case degree == 1:
+			report.DeadEnds++
+		case degree >= 3:
+			report.Junctions[degree]++
+		}
+	}
+
+	// Цикломатическое число графа: рёбра - вершины + компоненты связности.
+	report.Cycles = edges/2 - report.Passages + placement.Components(mz)
+
+	corridors, length := 0, 0
+
+	for _, corridor := range corridorsOf(mz) {
+		report.Corridors[corridor]++
+		corridors++
+		length += corridor
+	}
+
+	if corridors != 0 {
+		report.RiverFactor = float64(length) / float64(corridors)
+	}
+
+	return report
+}
+
+// Solve возвращает характеристики пути от start до end в mz с наименьшим количеством переходов.
+func Solve(mz maze.Maze, start, end cells.Coordinates) (Solution, error) {
+	result, err := bfs.NewSolver(sutils.DefaultCosts()).Solve(mz, start, end)
+	if err != nil {
+		return Solution{}, err
+	}
+
+	return Solution{Start: start, End: end, Length: len(result.Path) - 1, Turns: turns.Turns(result.Path)}, nil
+}
+
+// corridorsOf возвращает длины всех коридоров mz, включая циклы, все клетки которых имеют ровно два перехода.
+func corridorsOf(mz maze.Maze) []int {
+	var corridors []int
+
+	visited := make(map[cells.Coordinates]struct{})
+
+	for coords, cell := range mz.Cells {
+		if cell.Type == cells.Wall || len(cell.Transitions) == 2 {
+			continue
+		}
+
+		for _, next := range cell.Transitions {
+			if len(mz.Cells[next].Transitions) != 2 {
+				if isBefore(coords, next) { // Коридор без промежуточных клеток учитывается с одного из концов.
+					corridors = append(corridors, 1)
+				}
+
+				continue
+			}
+
+			if _, ok := visited[next]; ok { // Коридор уже пройден с другого конца.
+				continue
+			}
+
+			corridors = append(corridors, walk(mz, coords, next, visited))
+		}
+	}
+
+	for coords, cell := range mz.Cells { // Оставшиеся клетки с двумя переходами образуют изолированные циклы.
+		if _, ok := visited[coords]; ok || cell.Type == cells.Wall || len(cell.Transitions) != 2 {
+			continue
+		}
+
+		visited[coords] = struct{}{}
+		corridors = append(corridors, walk(mz, coords, cell.Transitions[0], visited))
+	}
+
+	return corridors
+}
+
+// walk проходит коридор из from через next, пока не встретит клетку не с двумя переходами или уже пройденную,
+// помечает его промежуточные клетки в visited и возвращает количество пройденных переходов.
+func walk(mz maze.Maze, from, next cells.Coordinates, visited map[cells.Coordinates]struct{}) int {
+	length := 1
+
+	for len(mz.Cells[next].Transitions) == 2 {
+		if _, ok := visited[next]; ok {
+			break
+		}
+
+		visited[next] = struct{}{}
+
+		following := mz.Cells[next].Transitions[0]
+		if following == from {
+			following = mz.Cells[next].Transitions[1]
+		}
+
+		from, next = next, following
+
+		length++
+	}
+
+	return length
+}
+
+// isBefore возвращает true, если a при построчном обходе встречается раньше b, иначе false.
+func isBefore(a, b cells.Coordinates) bool {
+	return a.Y < b.Y || a.Y == b.Y && a.X < b.X
+}
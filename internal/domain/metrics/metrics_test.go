@@ -0,0 +1,221 @@
+package metrics_test
+
+import (
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/cave"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/prim"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/wilson"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/metrics"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils/sutilstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyze(t *testing.T) {
+	tests := []struct {
+		name     string
+		mz       maze.Maze
+		expected metrics.Report
+	}{
+		{
+			name: "corridor",
+			mz:   sutilstest.NewGridMaze(1, 5),
+			expected: metrics.Report{
+				Height:      1,
+				Width:       5,
+				Passages:    5,
+				DeadEnds:    2,
+				Junctions:   map[int]int{},
+				Corridors:   map[int]int{4: 1},
+				RiverFactor: 4,
+				Terrain:     map[string]int{"Pass": 5},
+			},
+		},
+		{
+			name: "open grid",
+			mz:   sutilstest.NewGridMaze(3, 3),
+			expected: metrics.Report{
+				Height:      3,
+				Width:       3,
+				Passages:    9,
+				Junctions:   map[int]int{3: 4, 4: 1},
+				Corridors:   map[int]int{1: 4, 2: 4},
+				RiverFactor: 1.5,
+				Cycles:      4,
+				Terrain:     map[string]int{"Pass": 9},
+			},
+		},
+		{
+			name: "ring",
+			mz:   sutilstest.NewGridMaze(3, 3, cells.Coordinates{X: 1, Y: 1}),
+			expected: metrics.Report{
+				Height:      3,
+				Width:       3,
+				Passages:    8,
+				Junctions:   map[int]int{},
+				Corridors:   map[int]int{8: 1},
+				RiverFactor: 8,
+				Cycles:      1,
+				Terrain:     map[string]int{"Pass": 8, "Wall": 1},
+			},
+		},
+		{
+			name: "two components",
+			mz:   sutilstest.NewGridMaze(2, 3, cells.Coordinates{X: 1, Y: 0}, cells.Coordinates{X: 1, Y: 1}),
+			expected: metrics.Report{
+				Height:      2,
+				Width:       3,
+				Passages:    4,
+				DeadEnds:    4,
+				Junctions:   map[int]int{},
+				Corridors:   map[int]int{1: 2},
+				RiverFactor: 1,
+				Terrain:     map[string]int{"Pass": 4, "Wall": 2},
+			},
+		},
+		{
+			name: "no passages",
+			mz:   maze.New(2, 2),
+			expected: metrics.Report{
+				Height:    2,
+				Width:     2,
+				Junctions: map[int]int{},
+				Corridors: map[int]int{},
+				Terrain:   map[string]int{"Wall": 4},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, metrics.Analyze(tt.mz))
+		})
+	}
+}
+
+func TestAnalyzeGenerated(t *testing.T) {
+	tests := []struct {
+		name      string
+		generator interface {
+			Generate(height, width int) (maze.Maze, error)
+		}
+		isPerfect bool
+	}{
+		{name: "prim", generator: prim.NewGenerator(), isPerfect: true},
+		{name: "wilson", generator: wilson.NewGenerator(), isPerfect: true},
+		{name: "cave", generator: cave.NewGenerator()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mz, err := tt.generator.Generate(8, 10)
+			require.NoError(t, err)
+
+			report := metrics.Analyze(mz)
+
+			edges, degrees := 0, make(map[int]int)
+
+			for _, cell := range mz.Cells {
+				if cell.Type != cells.Wall {
+					edges += len(cell.Transitions)
+					degrees[len(cell.Transitions)]++
+				}
+			}
+
+			corridorsLength := 0
+			for length, count := range report.Corridors {
+				corridorsLength += length * count
+			}
+
+			assert.Equal(t, edges/2, corridorsLength) // Каждый переход принадлежит ровно одному коридору.
+			assert.Equal(t, degrees[1], report.DeadEnds)
+
+			if tt.isPerfect {
+				assert.Zero(t, report.Cycles)
+				assert.Equal(t, mz.Height*mz.Width, report.Passages)
+			}
+		})
+	}
+}
+
+func TestSolve(t *testing.T) {
+	tests := []struct {
+		name        string
+		mz          maze.Maze
+		start       cells.Coordinates
+		end         cells.Coordinates
+		expected    metrics.Solution
+		expectedErr error
+	}{
+		{
+			name:     "straight corridor",
+			mz:       sutilstest.NewGridMaze(1, 5),
+			start:    cells.Coordinates{X: 0, Y: 0},
+			end:      cells.Coordinates{X: 4, Y: 0},
+			expected: metrics.Solution{Start: cells.Coordinates{X: 0, Y: 0}, End: cells.Coordinates{X: 4, Y: 0}, Length: 4},
+		},
+		{
+			name: "bend",
+			mz: sutilstest.NewGridMaze(3, 3,
+				cells.Coordinates{X: 1, Y: 0}, cells.Coordinates{X: 2, Y: 0}, cells.Coordinates{X: 1, Y: 1}, cells.Coordinates{X: 2, Y: 1}),
+			start:    cells.Coordinates{X: 0, Y: 0},
+			end:      cells.Coordinates{X: 2, Y: 2},
+			expected: metrics.Solution{Start: cells.Coordinates{X: 0, Y: 0}, End: cells.Coordinates{X: 2, Y: 2}, Length: 4, Turns: 1},
+		},
+		{
+			name:  "zigzag",
+			mz:    sutilstest.NewGridMaze(3, 3, cells.Coordinates{X: 1, Y: 0}, cells.Coordinates{X: 1, Y: 2}),
+			start: cells.Coordinates{X: 0, Y: 0},
+			end:   cells.Coordinates{X: 2, Y: 2},
+			expected: metrics.Solution{
+				Start: cells.Coordinates{X: 0, Y: 0}, End: cells.Coordinates{X: 2, Y: 2}, Length: 4, Turns: 2,
+			},
+		},
+		{
+			name:     "start is end",
+			mz:       sutilstest.NewGridMaze(2, 2),
+			start:    cells.Coordinates{X: 1, Y: 1},
+			end:      cells.Coordinates{X: 1, Y: 1},
+			expected: metrics.Solution{Start: cells.Coordinates{X: 1, Y: 1}, End: cells.Coordinates{X: 1, Y: 1}},
+		},
+		{
+			name:        "unreachable end",
+			mz:          sutilstest.NewGridMaze(2, 3, cells.Coordinates{X: 1, Y: 0}, cells.Coordinates{X: 1, Y: 1}),
+			start:       cells.Coordinates{X: 0, Y: 0},
+			end:         cells.Coordinates{X: 2, Y: 1},
+			expectedErr: sutils.ErrUnreachable,
+		},
+		{
+			name:        "end in wall",
+			mz:          sutilstest.NewGridMaze(2, 3, cells.Coordinates{X: 1, Y: 0}),
+			start:       cells.Coordinates{X: 0, Y: 0},
+			end:         cells.Coordinates{X: 1, Y: 0},
+			expectedErr: sutils.ErrMaskedCell,
+		},
+		{
+			name:        "start out of bounds",
+			mz:          sutilstest.NewGridMaze(2, 3),
+			start:       cells.Coordinates{X: 3, Y: 0},
+			end:         cells.Coordinates{X: 1, Y: 0},
+			expectedErr: sutils.ErrOutOfBounds,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			solution, err := metrics.Solve(tt.mz, tt.start, tt.end)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, solution)
+		})
+	}
+}
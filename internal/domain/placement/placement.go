@@ -0,0 +1,388 @@
+package placement
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/gutils"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+)
+
+// Mode - способ расстановки начала и конца пути.
+type Mode string
+
+// Константы способов расстановки.
+const (
+	Manual   Mode = "manual"   // Координаты вводит пользователь.
+	Farthest Mode = "diameter" // Начало и конец - самые удалённые друг от друга клетки.
+	Border   Mode = "border"   // Начало и конец - случайные клетки на границе лабиринта.
+	Corners  Mode = "corners"  // Начало и конец - ближайшие к противоположным углам клетки.
+	Distance Mode = "distance" // Начало и конец - случайные клетки не ближе заданного расстояния.
+)
+
+const (
+	// distanceAttempts - количество случайных начал, перебираемых способом Distance до обращения к диаметру.
+	distanceAttempts = 16
+	// landmarkSweeps - количество пар ориентиров, по которым выбирается центр для поиска диаметра.
+	landmarkSweeps = 2
+)
+
+var (
+	// ErrNoPassages возвращается, если в лабиринте нет проходов.
+	ErrNoPassages = errors.New("maze has no passages")
+	// ErrNoCandidates возвращается, если в лабиринте нет двух клеток, подходящих способу расстановки.
+	ErrNoCandidates = errors.New("maze has no suitable pair of cells")
+	// ErrUnknownMode возвращается для неизвестного способа расстановки.
+	ErrUnknownMode = errors.New("unknown placement mode")
+)
+
+// Diameter возвращает две самые удалённые друг от друга по количеству шагов связанные клетки mz
+// и количество шагов между ними.
+func Diameter(mz maze.Maze) (a, b cells.Coordinates, length int, err error) {
+	g := newGraph(mz)
+
+	components := g.components()
+	if len(components) == 0 {
+		return cells.Coordinates{}, cells.Coordinates{}, 0, ErrNoPassages
+	}
+
+	length = -1
+
+	for _, component := range components {
+		ca, cb, cl := g.diameterOf(component)
+		if cl > length {
+			a, b, length = g.coords(ca), g.coords(cb), cl
+		}
+	}
+
+	return a, b, length, nil
+}
+
+// Components возвращает количество компонент связности проходов mz.
+func Components(mz maze.Maze) int {
+	return len(newGraph(mz).components())
+}
+
+// Place возвращает начало и конец пути в mz, расставленные способом mode в самой большой компоненте связности,
+// чтобы путь между ними существовал; minDistance - наименьшее количество шагов между ними для способа Distance.
+func Place(mz maze.Maze, mode Mode, minDistance int) (start, end cells.Coordinates, err error) {
+	g := newGraph(mz)
+
+	components := g.components()
+	if len(components) == 0 {
+		return cells.Coordinates{}, cells.Coordinates{}, ErrNoPassages
+	}
+
+	largest := components[0]
+	for _, component := range components {
+		if len(component) > len(largest) {
+			largest = component
+		}
+	}
+
+	switch mode {
+	case Farthest:
+		a, b, length := g.diameterOf(largest)
+		if length == 0 {
+			return cells.Coordinates{}, cells.Coordinates{}, fmt.Errorf("diameter: %w", ErrNoCandidates)
+		}
+
+		return g.coords(a), g.coords(b), nil
+	case Border:
+		return g.placeOnBorder(largest)
+	case Corners:
+		return g.placeInCorners(largest)
+	case Distance:
+		return g.placeApart(largest, minDistance)
+	default:
+		return cells.Coordinates{}, cells.Coordinates{}, fmt.Errorf("mode %q: %w", mode, ErrUnknownMode)
+	}
+}
+
+// graph - проходы лабиринта, пронумерованные построчно, со списками смежных проходов.
+type graph struct {
+	height  int
+	width   int
+	passage []bool
+	links   [][]int
+	search  search
+}
+
+// search - результат последнего поиска в ширину: количество шагов до каждой клетки (-1 для недостигнутых)
+// и достигнутые клетки в порядке обхода, то есть по неубыванию расстояния.
+type search struct {
+	steps []int
+	order []int
+}
+
+// newGraph возвращает граф проходов mz.
+func newGraph(mz maze.Maze) *graph {
+	g := &graph{
+		height:  mz.Height,
+		width:   mz.Width,
+		passage: make([]bool, mz.Height*mz.Width),
+		links:   make([][]int, mz.Height*mz.Width),
+		search:  search{steps: make([]int, mz.Height*mz.Width)},
+	}
+
+	for coords, cell := range mz.Cells {
+		if cell.Type == cells.Wall {
+			continue
+		}
+
+		index := g.index(coords)
+		g.passage[index] = true
+
+		for _, next := range cell.Transitions {
+			g.links[index] = append(g.links[index], g.index(next))
+		}
+	}
+
+	for i := range g.search.steps {
+		g.search.steps[i] = -1
+	}
+
+	return g
+}
+
+// index возвращает номер клетки coords.
+func (g *graph) index(coords cells.Coordinates) int {
+	return coords.Y*g.width + coords.X
+}
+
+// coords возвращает координаты клетки с номером index.
+func (g *graph) coords(index int) cells.Coordinates {
+	return cells.Coordinates{X: index % g.width, Y: index / g.width}
+}
+
+// bfs обходит в ширину клетки, достижимые из source, и сохраняет результат в g.search.
+func (g *graph) bfs(source int) {
+	for _, index := range g.search.order {
+		g.search.steps[index] = -1
+	}
+
+	g.search.steps[source] = 0
+	g.search.order = append(g.search.order[:0], source)
+
+	for i := 0; i < len(g.search.order); i++ {
+		current := g.search.order[i]
+
+		for _, next := range g.links[current] {
+			if g.search.steps[next] == -1 {
+				g.search.steps[next] = g.search.steps[current] + 1
+				g.search.order = append(g.search.order, next)
+			}
+		}
+	}
+}
+
+// farthest возвращает клетку, самую удалённую от source, и количество шагов до неё.
+func (g *graph) farthest(source int) (index, steps int) {
+	g.bfs(source)
+
+	index = g.search.order[len(g.search.order)-1]
+
+	return index, g.search.steps[index]
+}
+
+// components возвращает компоненты связности проходов.
+func (g *graph) components() [][]int {
+	var components [][]int
+
+	visited := make([]bool, len(g.links))
+
+	for index := range g.links {
+		if visited[index] || !g.passage[index] {
+			continue
+		}
+
+		g.bfs(index)
+
+		component := append([]int(nil), g.search.order...)
+		for _, c := range component {
+			visited[c] = true
+		}
+
+		components = append(components, component)
+	}
+
+	return components
+}
+
+// diameterOf возвращает две самые удалённые друг от друга клетки компоненты связности component
+// и количество шагов между ними.
+func (g *graph) diameterOf(component []int) (a, b, length int) {
+	// Суть поиска диаметра:
+	//
+	// В дереве самая удалённая от любой клетки клетка - конец диаметра, поэтому хватает двух поисков в ширину.
+	// В графе с циклами это лишь нижняя оценка, и точный диаметр находится алгоритмом iFUB:
+	// 1) Концы нескольких таких пар служат ориентирами, и центром выбирается клетка, наименее удалённая
+	//    от самого дальнего из них: чем меньше эксцентриситет центра, тем раньше остановится перебор.
+	// 2) Из центра строится поиск в ширину, разбивающий клетки на слои по расстоянию i.
+	// 3) Любые две клетки из слоёв не дальше i удалены друг от друга не больше чем на 2 * i, поэтому
+	//    клетки перебираются от дальнего слоя, и для каждой вычисляется её эксцентриситет.
+	// 4) Как только лучший эксцентриситет не меньше 2 * i для слоя i очередной клетки, он и есть диаметр.
+	//
+	// Обычно алгоритм останавливается после нескольких слоёв, а в худшем случае совпадает
+	// с поиском в ширину из каждой клетки.
+	a, _ = g.farthest(component[0])
+	b, length = g.farthest(a)
+
+	edges := 0
+	for _, index := range component {
+		edges += len(g.links[index])
+	}
+
+	if edges/2 == len(component)-1 { // Дерево.
+		return a, b, length
+	}
+
+	reach := make([]int, len(g.links))
+	x, y := a, b
+	center := component[0]
+
+	for range landmarkSweeps {
+		for _, landmark := range []int{x, y} {
+			g.bfs(landmark)
+
+			for _, index := range component {
+				reach[index] = max(reach[index], g.search.steps[index])
+			}
+		}
+
+		for _, index := range component {
+			if reach[index] < reach[center] {
+				center = index
+			}
+		}
+
+		var steps int
+
+		x, _ = g.farthest(center)
+		if y, steps = g.farthest(x); steps > length {
+			a, b, length = x, y, steps
+		}
+	}
+
+	g.bfs(center)
+
+	order := append([]int(nil), g.search.order...)
+	layers := append([]int(nil), g.search.steps...)
+
+	for i := len(order) - 1; i >= 0 && length < 2*layers[order[i]]; i-- {
+		if far, steps := g.farthest(order[i]); steps > length {
+			a, b, length = order[i], far, steps
+		}
+	}
+
+	return a, b, length
+}
+
+// placeOnBorder возвращает две различные случайные клетки component на границе лабиринта.
+func (g *graph) placeOnBorder(component []int) (start, end cells.Coordinates, err error) {
+	var border []cells.Coordinates
+
+	for _, index := range component {
+		coords := g.coords(index)
+		if coords.X == 0 || coords.Y == 0 || coords.X == g.width-1 || coords.Y == g.height-1 {
+			border = append(border, coords)
+		}
+	}
+
+	if len(border) < 2 {
+		return cells.Coordinates{}, cells.Coordinates{}, fmt.Errorf("border: %w", ErrNoCandidates)
+	}
+
+	err = gutils.Shuffle(border)
+	if err != nil {
+		return cells.Coordinates{}, cells.Coordinates{}, fmt.Errorf("can`t shuffle border cells: %w", err)
+	}
+
+	return border[0], border[1], nil
+}
+
+// placeInCorners возвращает клетки component, ближайшие к левому верхнему и правому нижнему углам лабиринта.
+func (g *graph) placeInCorners(component []int) (start, end cells.Coordinates, err error) {
+	corner := g.coords(len(g.links) - 1)
+	start, end = g.coords(component[0]), g.coords(component[0])
+
+	for _, index := range component {
+		coords := g.coords(index)
+
+		if isCloser(coords, start, cells.Coordinates{}) {
+			start = coords
+		}
+
+		if isCloser(coords, end, corner) {
+			end = coords
+		}
+	}
+
+	if start == end {
+		return cells.Coordinates{}, cells.Coordinates{}, fmt.Errorf("corners: %w", ErrNoCandidates)
+	}
+
+	return start, end, nil
+}
+
+// placeApart возвращает две случайные клетки component, между которыми не меньше minDistance шагов.
+func (g *graph) placeApart(component []int, minDistance int) (start, end cells.Coordinates, err error) {
+	var number int
+
+	for range distanceAttempts {
+		number, err = gutils.GetRandomInt(len(component))
+		if err != nil {
+			return cells.Coordinates{}, cells.Coordinates{}, fmt.Errorf("can`t choose random start: %w", err)
+		}
+
+		g.bfs(component[number])
+		order := g.search.order
+
+		// Клетки в order упорядочены по расстоянию, поэтому подходящие составляют его хвост.
+		first := len(order)
+		for first > 0 && g.search.steps[order[first-1]] >= max(minDistance, 1) {
+			first--
+		}
+
+		if first == len(order) {
+			continue
+		}
+
+		number, err = gutils.GetRandomInt(len(order) - first)
+		if err != nil {
+			return cells.Coordinates{}, cells.Coordinates{}, fmt.Errorf("can`t choose random end: %w", err)
+		}
+
+		return g.coords(order[0]), g.coords(order[first+number]), nil
+	}
+
+	// Случайные начала могли оказаться в центре; если подходящая пара есть, ею будут концы диаметра.
+	a, b, length := g.diameterOf(component)
+	if length < max(minDistance, 1) {
+		return cells.Coordinates{}, cells.Coordinates{}, fmt.Errorf("distance %d: %w", minDistance, ErrNoCandidates)
+	}
+
+	return g.coords(a), g.coords(b), nil
+}
+
+// isCloser возвращает true, если a ближе к corner, чем b, а при равном расстоянии - выше или левее b, иначе false.
+func isCloser(a, b, corner cells.Coordinates) bool {
+	da, db := distanceTo(a, corner), distanceTo(b, corner)
+
+	return da < db || da == db && (a.Y < b.Y || a.Y == b.Y && a.X < b.X)
+}
+
+// distanceTo возвращает манхэттенское расстояние между a и b.
+func distanceTo(a, b cells.Coordinates) int {
+	return abs(a.X-b.X) + abs(a.Y-b.Y)
+}
+
+// abs возвращает модуль x.
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+
+	return x
+}
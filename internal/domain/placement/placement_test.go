@@ -0,0 +1,269 @@
+package placement_test
+
+import (
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/cave"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/prim"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/placement"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils/sutilstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiameter(t *testing.T) {
+	tests := []struct {
+		name string
+		mz   func(t *testing.T) maze.Maze
+	}{
+		{
+			name: "corridor",
+			mz:   func(*testing.T) maze.Maze { return sutilstest.NewGridMaze(1, 7) },
+		},
+		{
+			name: "open grid",
+			mz:   func(*testing.T) maze.Maze { return sutilstest.NewGridMaze(6, 9) },
+		},
+		{
+			name: "perfect maze",
+			mz: func(t *testing.T) maze.Maze {
+				mz, err := prim.NewGenerator().Generate(15, 15)
+				require.NoError(t, err)
+
+				return mz
+			},
+		},
+		{
+			name: "braided maze",
+			mz:   func(t *testing.T) maze.Maze { return sutilstest.NewBraidedMaze(t, 15, 15) },
+		},
+		{
+			name: "cave",
+			mz: func(t *testing.T) maze.Maze {
+				mz, err := cave.NewGenerator().Generate(24, 24)
+				require.NoError(t, err)
+
+				return mz
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for range 5 {
+				mz := tt.mz(t)
+
+				a, b, length, err := placement.Diameter(mz)
+				require.NoError(t, err)
+
+				assert.Equal(t, bruteForceDiameter(mz), length)
+				assert.Equal(t, length, stepsFrom(mz, a)[b])
+			}
+		})
+	}
+}
+
+func TestDiameterWithoutPassages(t *testing.T) {
+	_, _, _, err := placement.Diameter(maze.New(3, 3))
+
+	assert.ErrorIs(t, err, placement.ErrNoPassages)
+}
+
+func TestComponents(t *testing.T) {
+	tests := []struct {
+		name     string
+		mz       maze.Maze
+		expected int
+	}{
+		{
+			name:     "no passages",
+			mz:       maze.New(3, 3),
+			expected: 0,
+		},
+		{
+			name:     "open grid",
+			mz:       sutilstest.NewGridMaze(3, 3),
+			expected: 1,
+		},
+		{
+			name:     "grid split by wall column",
+			mz:       sutilstest.NewGridMaze(2, 3, cells.Coordinates{X: 1, Y: 0}, cells.Coordinates{X: 1, Y: 1}),
+			expected: 2,
+		},
+		{
+			name:     "unlinked passages",
+			mz:       sutilstest.NewUnlinkedMaze(2, 2),
+			expected: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, placement.Components(tt.mz))
+		})
+	}
+}
+
+func TestPlace(t *testing.T) {
+	perfect, err := prim.NewGenerator().Generate(15, 15)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		mz          maze.Maze
+		mode        placement.Mode
+		minDistance int
+		check       func(t *testing.T, mz maze.Maze, start, end cells.Coordinates)
+		expectedErr error
+	}{
+		{
+			name: "diameter of open grid joins opposite corners",
+			mz:   sutilstest.NewGridMaze(5, 7),
+			mode: placement.Farthest,
+			check: func(t *testing.T, mz maze.Maze, start, end cells.Coordinates) {
+				t.Helper()
+				assert.Equal(t, 10, stepsFrom(mz, start)[end])
+			},
+		},
+		{
+			name: "corners skip walls",
+			mz:   sutilstest.NewGridMaze(5, 5, cells.Coordinates{X: 0, Y: 0}, cells.Coordinates{X: 4, Y: 4}),
+			mode: placement.Corners,
+			check: func(t *testing.T, _ maze.Maze, start, end cells.Coordinates) {
+				t.Helper()
+				assert.Equal(t, cells.Coordinates{X: 1, Y: 0}, start)
+				assert.Equal(t, cells.Coordinates{X: 4, Y: 3}, end)
+			},
+		},
+		{
+			name: "border cells",
+			mz:   perfect,
+			mode: placement.Border,
+			check: func(t *testing.T, mz maze.Maze, start, end cells.Coordinates) {
+				t.Helper()
+				assert.NotEqual(t, start, end)
+				assert.True(t, isOnBorder(mz, start))
+				assert.True(t, isOnBorder(mz, end))
+			},
+		},
+		{
+			name:        "cells at least minimal distance apart",
+			mz:          perfect,
+			mode:        placement.Distance,
+			minDistance: 30,
+			check: func(t *testing.T, mz maze.Maze, start, end cells.Coordinates) {
+				t.Helper()
+				assert.GreaterOrEqual(t, stepsFrom(mz, start)[end], 30)
+			},
+		},
+		{
+			name: "cells of largest component",
+			mz: sutilstest.NewGridMaze(4, 7,
+				cells.Coordinates{X: 2, Y: 0}, cells.Coordinates{X: 2, Y: 1}, cells.Coordinates{X: 2, Y: 2}, cells.Coordinates{X: 2, Y: 3}),
+			mode: placement.Corners,
+			check: func(t *testing.T, _ maze.Maze, start, end cells.Coordinates) {
+				t.Helper()
+				assert.Equal(t, cells.Coordinates{X: 3, Y: 0}, start)
+				assert.Equal(t, cells.Coordinates{X: 6, Y: 3}, end)
+			},
+		},
+		{
+			name:        "minimal distance is too large",
+			mz:          sutilstest.NewGridMaze(5, 7),
+			mode:        placement.Distance,
+			minDistance: 11,
+			expectedErr: placement.ErrNoCandidates,
+		},
+		{
+			name:        "border has no passages",
+			mz:          sutilstest.NewGridMaze(3, 3, borderOf(3, 3)...),
+			mode:        placement.Border,
+			expectedErr: placement.ErrNoCandidates,
+		},
+		{
+			name:        "maze has no passages",
+			mz:          maze.New(3, 3),
+			mode:        placement.Farthest,
+			expectedErr: placement.ErrNoPassages,
+		},
+		{
+			name:        "unknown mode",
+			mz:          sutilstest.NewGridMaze(3, 3),
+			mode:        placement.Mode("center"),
+			expectedErr: placement.ErrUnknownMode,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, err := placement.Place(tt.mz, tt.mode, tt.minDistance)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			tt.check(t, tt.mz, start, end)
+		})
+	}
+}
+
+// bruteForceDiameter возвращает диаметр mz, найденный поиском в ширину из каждой клетки.
+func bruteForceDiameter(mz maze.Maze) int {
+	diameter := 0
+
+	for coords, cell := range mz.Cells {
+		if cell.Type == cells.Wall {
+			continue
+		}
+
+		for _, steps := range stepsFrom(mz, coords) {
+			diameter = max(diameter, steps)
+		}
+	}
+
+	return diameter
+}
+
+// stepsFrom возвращает количество шагов от source до каждой достижимой клетки.
+func stepsFrom(mz maze.Maze, source cells.Coordinates) map[cells.Coordinates]int {
+	steps := map[cells.Coordinates]int{source: 0}
+	queue := []cells.Coordinates{source}
+
+	for len(queue) != 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, next := range mz.Cells[current].Transitions {
+			if _, ok := steps[next]; !ok {
+				steps[next] = steps[current] + 1
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	return steps
+}
+
+// isOnBorder проверяет, что coords лежит на границе mz.
+func isOnBorder(mz maze.Maze, coords cells.Coordinates) bool {
+	return coords.X == 0 || coords.Y == 0 || coords.X == mz.Width-1 || coords.Y == mz.Height-1
+}
+
+// borderOf возвращает клетки границы лабиринта высотой height и шириной width.
+func borderOf(height, width int) []cells.Coordinates {
+	var border []cells.Coordinates
+
+	for y := range height {
+		for x := range width {
+			if x == 0 || y == 0 || x == width-1 || y == height-1 {
+				border = append(border, cells.Coordinates{X: x, Y: y})
+			}
+		}
+	}
+
+	return border
+}
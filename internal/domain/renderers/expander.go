@@ -4,8 +4,11 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/gutils"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/tremaux"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/file"
 )
 
@@ -18,12 +21,14 @@ const (
 
 // expanderRenderer - структура "расширяющего" рендера.
 type expanderRenderer struct {
-	palette Palette
+	palette   Palette
+	outerWall bool // Если true, лабиринт выводится вместе с внешней стеной, в которой прорезаны вход и выход.
 }
 
-// newExpanderRenderer возвращает указатель на инициализированный expanderRenderer.
-func newExpanderRenderer() (*expanderRenderer, error) {
-	eR := expanderRenderer{}
+// newExpanderRenderer возвращает указатель на инициализированный expanderRenderer,
+// выводящий внешнюю стену лабиринта при outerWall = true.
+func newExpanderRenderer(outerWall bool) (*expanderRenderer, error) {
+	eR := expanderRenderer{outerWall: outerWall}
 
 	err := file.LoadData(pathToPalette, &eR.palette)
 	if err != nil {
@@ -35,41 +40,151 @@ func newExpanderRenderer() (*expanderRenderer, error) {
 
 // Render отображает лабиринт в готовую для визуализации строку и возвращает её.
 func (r *expanderRenderer) Render(mz maze.Maze) string {
-	return convertToString(expandMaze(mz), r.palette)
+	return r.convert(expandMaze(mz))
 }
 
 // RenderPath отображает лабиринт и путь в нём в готовую для визуализации строку и возвращает её.
 func (r *expanderRenderer) RenderPath(mz maze.Maze, path []cells.Coordinates) string {
-	return convertToString(expandMaze(overlayPath(mz, path)), r.palette)
+	return r.convert(expandMaze(overlayPath(clone(mz), path)))
 }
 
-// expandMaze возвращает расширенный лабиринт, в котором появляются стены.
+// RenderFilled отображает лабиринт, заполненные клетки и путь в готовую для визуализации строку и возвращает её.
+func (r *expanderRenderer) RenderFilled(
+	mz maze.Maze,
+	path []cells.Coordinates,
+	filled map[cells.Coordinates]struct{},
+) string {
+	return r.convert(expandMaze(overlayPath(overlayFilled(clone(mz), filled), path)))
+}
+
+// RenderMarks отображает лабиринт, путь и проходы, помеченные алгоритмом Тремо один и два раза,
+// в готовую для визуализации строку и возвращает её; метки ставятся на рёбрах расширенного лабиринта.
+func (r *expanderRenderer) RenderMarks(mz maze.Maze, path []cells.Coordinates, marks map[tremaux.Passage]int) string {
+	expandedMaze := expandMaze(overlayPath(clone(mz), path))
+
+	for passage, count := range marks {
+		from, to := expand(passage.From), expand(passage.To)
+		middle := expandedMaze.Cells[cells.Coordinates{X: (from.X + to.X) / 2, Y: (from.Y + to.Y) / 2}]
+
+		switch {
+		case middle.Type == Path: // Проходы пути помечены единожды и уже окрашены как путь.
+		case count == 1:
+			middle.Type = MarkedOnce
+		default:
+			middle.Type = MarkedTwice
+		}
+	}
+
+	return r.convert(expandedMaze)
+}
+
+// RenderTrace отображает состояние поиска после событий events в готовую для визуализации строку и возвращает её.
+func (r *expanderRenderer) RenderTrace(mz maze.Maze, events []sutils.Event) string {
+	frontier := make(map[cells.Coordinates]struct{})
+	explored := make(map[cells.Coordinates]struct{})
+
+	var path []cells.Coordinates
+
+	for _, event := range events {
+		switch event.Kind {
+		case sutils.Pushed:
+			frontier[event.Vertex] = struct{}{}
+		case sutils.Popped:
+			delete(frontier, event.Vertex)
+			explored[event.Vertex] = struct{}{}
+		case sutils.PathFinalized:
+			path = append(path, event.Vertex)
+		case sutils.PredecessorSet: // Предшественники не отображаются.
+		}
+	}
+
+	mz = overlaySet(overlaySet(clone(mz), explored, Explored), frontier, Frontier)
+
+	return r.convert(expandMaze(overlayPath(mz, path)))
+}
+
+// RenderPaths отображает лабиринт и пути, окрашенные в разные цвета, в готовую для визуализации строку и возвращает её;
+// клетка, общая для нескольких путей, окрашивается в цвет первого из них.
+func (r *expanderRenderer) RenderPaths(mz maze.Maze, paths [][]cells.Coordinates) string {
+	mz = clone(mz)
+
+	for i := len(paths) - 1; i >= 0; i-- {
+		for _, coords := range paths[i] {
+			mz.Cells[coords].Type = Alternatives[i%len(Alternatives)]
+		}
+	}
+
+	if len(paths) != 0 { // Начало и конец у всех путей общие.
+		mz.Cells[paths[0][0]].Type = Start
+		mz.Cells[paths[0][len(paths[0])-1]].Type = End
+	}
+
+	return r.convert(expandMaze(mz))
+}
+
+// expandMaze возвращает расширенный лабиринт, в котором появляются стены и окружающая его внешняя стена;
+// у начальной и конечной клеток, лежащих на границе, во внешней стене прорезаются вход и выход.
 func expandMaze(mz maze.Maze) maze.Maze {
-	expandedMaze := maze.New(2*mz.Height-1, 2*mz.Width-1) // Между строками и столбцами появляются новые.
+	// Между строками и столбцами и вокруг них появляются новые.
+	expandedMaze := maze.New(2*mz.Height+1, 2*mz.Width+1)
 
 	for coords, cell := range mz.Cells {
-		expandedCoords := cells.Coordinates{X: 2 * coords.X, Y: 2 * coords.Y} // Отображение координат исходного лабиринта в расширенный.
+		expandedCoords := expand(coords) // Отображение координат исходного лабиринта в расширенный.
 
 		expandedMaze.Cells[expandedCoords].Type = mz.Cells[coords].Type // Перенос типа клетки.
 
 		for _, adjacentCoords := range cell.Transitions {
 			expandedMaze.Cells[expandedCoords].Transitions = append(
 				expandedMaze.Cells[expandedCoords].Transitions,
-				cells.Coordinates{
-					X: 2 * adjacentCoords.X, // Отображение координаты X клетки, куда есть переход исходного лабиринта в расширенный.
-					Y: 2 * adjacentCoords.Y, // Отображение координаты Y клетки, куда есть переход исходного лабиринта в расширенный.
-				},
+				expand(adjacentCoords), // Отображение координат клетки, куда есть переход, исходного лабиринта в расширенный.
 			)
 		}
 	}
 
-	return cutEdges(expandedMaze)
+	return cutEntrances(cutEdges(expandedMaze), mz)
+}
+
+// expand возвращает координаты клетки расширенного лабиринта, в которую отображается клетка coords исходного.
+func expand(coords cells.Coordinates) cells.Coordinates {
+	return cells.Coordinates{X: 2*coords.X + 1, Y: 2*coords.Y + 1}
+}
+
+// shrink возвращает координаты клетки исходного лабиринта, которая отображается в клетку coords расширенного;
+// ok = false, если coords - стена или ребро, а не отображение клетки исходного лабиринта.
+func shrink(coords cells.Coordinates) (original cells.Coordinates, ok bool) {
+	return cells.Coordinates{X: coords.X / 2, Y: coords.Y / 2}, coords.X%2 == 1 && coords.Y%2 == 1
+}
+
+// cutEntrances возвращает расширенный лабиринт, во внешней стене которого у начальной и конечной клеток
+// исходного лабиринта mz, лежащих на его границе, прорезаны клетки типа edge.
+func cutEntrances(expandedMaze, mz maze.Maze) maze.Maze {
+	for coords, cell := range mz.Cells {
+		if cell.Type != Start && cell.Type != End {
+			continue
+		}
+
+		for i := range gutils.Dx { // Угловая клетка получает проход лишь в одну из двух сторон.
+			if _, ok := mz.Cells[cells.Coordinates{X: coords.X + gutils.Dx[i], Y: coords.Y + gutils.Dy[i]}]; ok {
+				continue // Соседняя клетка лежит внутри лабиринта.
+			}
+
+			expandedCoords := expand(coords)
+			gap := expandedMaze.Cells[cells.Coordinates{X: expandedCoords.X + gutils.Dx[i], Y: expandedCoords.Y + gutils.Dy[i]}]
+
+			gap.Type = edge
+			gap.Transitions = append(gap.Transitions, expandedCoords)
+
+			break
+		}
+	}
+
+	return expandedMaze
 }
 
 // cutEdges возвращает лабиринт, в котором между отображёнными в расширенный клетками появляются клетки типа edge.
 func cutEdges(mz maze.Maze) maze.Maze {
 	for coords, cell := range mz.Cells {
-		if coords.X%2 == 0 && coords.Y%2 == 0 { // По формуле отображения лишь чётные координаты имеют смысловую нагрузку.
+		if _, ok := shrink(coords); ok { // По формуле отображения лишь нечётные координаты имеют смысловую нагрузку.
 			for _, adjacentCoords := range cell.Transitions {
 				edgeCoords := cells.Coordinates{
 					X: (coords.X + adjacentCoords.X) / 2, // X получается по формуле середины отрезка.
@@ -79,9 +194,12 @@ func cutEdges(mz maze.Maze) maze.Maze {
 				_, ok1 := pathParts[cell.Type]
 				_, ok2 := pathParts[mz.Cells[adjacentCoords].Type]
 
-				if ok1 && ok2 { // Если прорезаемое ребро принадлежит пути.
+				switch {
+				case ok1 && ok2: // Если прорезаемое ребро принадлежит пути.
 					mz.Cells[edgeCoords].Type = Path
-				} else {
+				case cell.Type == mz.Cells[adjacentCoords].Type && isOverlay(cell.Type): // Если ребро внутри одного наложения.
+					mz.Cells[edgeCoords].Type = cell.Type
+				default:
 					mz.Cells[edgeCoords].Type = edge
 				}
 
@@ -117,13 +235,29 @@ func overlayPath(mz maze.Maze, path []cells.Coordinates) maze.Maze {
 	return mz
 }
 
-// convertToString возвращает готовый к отображению лабиринт в форме строки.
-func convertToString(mz maze.Maze, palette Palette) string {
+// overlayFilled помечает клетки не расширенного лабиринта, принадлежащие filled, как Filled.
+func overlayFilled(mz maze.Maze, filled map[cells.Coordinates]struct{}) maze.Maze {
+	return overlaySet(mz, filled, Filled)
+}
+
+// overlaySet помечает клетки не расширенного лабиринта, принадлежащие set, типом t.
+func overlaySet(mz maze.Maze, set map[cells.Coordinates]struct{}, t cells.Type) maze.Maze {
+	for coords := range set {
+		mz.Cells[coords].Type = t
+	}
+
+	return mz
+}
+
+// convert возвращает готовый к отображению расширенный лабиринт в форме строки.
+func (r *expanderRenderer) convert(expandedMaze maze.Maze) string {
 	var result strings.Builder
 
-	for y := range mz.Height {
-		for x := range mz.Width {
-			result.WriteString(palette[mz.Cells[cells.Coordinates{X: x, Y: y}].Type])
+	margin := r.margin()
+
+	for y := margin; y < expandedMaze.Height-margin; y++ {
+		for x := margin; x < expandedMaze.Width-margin; x++ {
+			result.WriteString(r.palette[expandedMaze.Cells[cells.Coordinates{X: x, Y: y}].Type])
 		}
 
 		result.WriteString("\n")
@@ -131,3 +265,12 @@ func convertToString(mz maze.Maze, palette Palette) string {
 
 	return result.String()
 }
+
+// margin возвращает толщину внешней стены расширенного лабиринта, которая не выводится.
+func (r *expanderRenderer) margin() int {
+	if r.outerWall {
+		return 0
+	}
+
+	return 1
+}
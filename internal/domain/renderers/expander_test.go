@@ -0,0 +1,160 @@
+package renderers_test
+
+import (
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/renderers"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils/sutilstest"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/tremaux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandMazeEntrances(t *testing.T) {
+	tests := []struct {
+		name     string
+		marks    map[cells.Coordinates]cells.Type
+		expected []cells.Coordinates // Прорезанные клетки внешней стены расширенного лабиринта.
+	}{
+		{
+			name: "closed wall without path",
+		},
+		{
+			name:  "inner start and end",
+			marks: map[cells.Coordinates]cells.Type{{X: 1, Y: 1}: renderers.Start, {X: 2, Y: 1}: renderers.End},
+		},
+		{
+			name:     "border start and end",
+			marks:    map[cells.Coordinates]cells.Type{{X: 1, Y: 0}: renderers.Start, {X: 3, Y: 1}: renderers.End},
+			expected: []cells.Coordinates{{X: 3, Y: 0}, {X: 8, Y: 3}},
+		},
+		{
+			name:     "corner start gets one gap",
+			marks:    map[cells.Coordinates]cells.Type{{X: 0, Y: 0}: renderers.Start, {X: 2, Y: 2}: renderers.End},
+			expected: []cells.Coordinates{{X: 0, Y: 1}, {X: 5, Y: 6}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mz := sutilstest.NewGridMaze(3, 4)
+			for coords, mark := range tt.marks {
+				mz.Cells[coords].Type = mark
+			}
+
+			expanded := renderers.ExpandMaze(mz)
+			require.Equal(t, 7, expanded.Height)
+			require.Equal(t, 9, expanded.Width)
+
+			var gaps []cells.Coordinates
+
+			for y := range expanded.Height {
+				for x := range expanded.Width {
+					coords := cells.Coordinates{X: x, Y: y}
+					isBorder := x == 0 || y == 0 || x == expanded.Width-1 || y == expanded.Height-1
+
+					if isBorder && expanded.Cells[coords].Type != cells.Wall {
+						assert.Equal(t, renderers.Edge, expanded.Cells[coords].Type)
+
+						gaps = append(gaps, coords)
+					}
+				}
+			}
+
+			assert.ElementsMatch(t, tt.expected, gaps)
+		})
+	}
+}
+
+func TestExpandedDistance(t *testing.T) {
+	mz := sutilstest.NewGridMaze(2, 2)
+	distances := map[cells.Coordinates]sutils.Cost{{X: 0, Y: 0}: 0, {X: 1, Y: 0}: 4, {X: 0, Y: 1}: 2}
+	expanded := renderers.ExpandMaze(mz)
+
+	tests := []struct {
+		name     string
+		coords   cells.Coordinates
+		expected sutils.Cost
+		ok       bool
+	}{
+		{name: "original cell", coords: cells.Coordinates{X: 3, Y: 1}, expected: 4, ok: true},
+		{name: "edge between cells", coords: cells.Coordinates{X: 2, Y: 1}, expected: 2, ok: true},
+		{name: "original cell without distance", coords: cells.Coordinates{X: 3, Y: 3}},
+		{name: "edge to cell without distance", coords: cells.Coordinates{X: 3, Y: 2}},
+		{name: "outer wall", coords: cells.Coordinates{X: 0, Y: 0}},
+		{name: "inner wall", coords: cells.Coordinates{X: 2, Y: 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, ok := renderers.ExpandedDistance(expanded, tt.coords, distances)
+
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, d)
+		})
+	}
+}
+
+func TestExpanderRendererRenderMarks(t *testing.T) {
+	palette := renderers.Palette{
+		cells.Wall:            "#",
+		cells.Pass:            ".",
+		renderers.Edge:        "-",
+		renderers.Start:       "S",
+		renderers.End:         "E",
+		renderers.Path:        "*",
+		renderers.MarkedOnce:  "1",
+		renderers.MarkedTwice: "2",
+	}
+
+	// Коридор из трёх клеток: в расширенном лабиринте между ними лежат рёбра x = 2 и x = 4 строки y = 1.
+	left, middle, right := cells.Coordinates{X: 0, Y: 0}, cells.Coordinates{X: 1, Y: 0}, cells.Coordinates{X: 2, Y: 0}
+
+	tests := []struct {
+		name      string
+		path      []cells.Coordinates
+		marks     map[tremaux.Passage]int
+		outerWall bool
+		expected  string
+	}{
+		{
+			name:      "passages marked once and twice",
+			marks:     map[tremaux.Passage]int{tremaux.NewPassage(left, middle): 2, tremaux.NewPassage(middle, right): 1},
+			outerWall: true,
+			expected:  "#######\n#.2.1.#\n#######\n",
+		},
+		{
+			name:      "path keeps its colour over single marks",
+			path:      []cells.Coordinates{left, middle},
+			marks:     map[tremaux.Passage]int{tremaux.NewPassage(left, middle): 1, tremaux.NewPassage(right, middle): 2},
+			outerWall: true,
+			expected:  "###-###\n-S*E2.#\n#######\n",
+		},
+		{
+			name:      "no marks",
+			outerWall: true,
+			expected:  "#######\n#.-.-.#\n#######\n",
+		},
+		{
+			name:     "passages marked once and twice without outer wall",
+			marks:    map[tremaux.Passage]int{tremaux.NewPassage(left, middle): 2, tremaux.NewPassage(middle, right): 1},
+			expected: ".2.1.\n",
+		},
+		{
+			name:     "path without outer wall has no entrance gaps",
+			path:     []cells.Coordinates{left, middle},
+			marks:    map[tremaux.Passage]int{tremaux.NewPassage(left, middle): 1, tremaux.NewPassage(right, middle): 2},
+			expected: "S*E2.\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := renderers.NewExpanderRenderer(palette, tt.outerWall)
+
+			assert.Equal(t, tt.expected, r.RenderMarks(sutilstest.NewGridMaze(1, 3), tt.path, tt.marks))
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package renderers
+
+// Экспорт внутренних функций и констант для тестов пакета renderers_test.
+var (
+	ExpandMaze       = expandMaze
+	ExpandedDistance = expandedDistance
+)
+
+// Edge - вспомогательный тип клетки расширенного лабиринта, помечающий ребро исходного.
+const Edge = edge
+
+// NewExpanderRenderer возвращает расширяющий рендер с палитрой palette вместо загружаемой из файла.
+func NewExpanderRenderer(palette Palette, outerWall bool) *expanderRenderer {
+	return &expanderRenderer{palette: palette, outerWall: outerWall}
+}
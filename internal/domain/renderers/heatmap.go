@@ -0,0 +1,93 @@
+package renderers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils"
+)
+
+// heatCellFormat - формат клетки тепловой карты: два пробела на фоне цвета из 256-цветной палитры ANSI.
+const heatCellFormat = "\033[48;5;%dm  \033[0m"
+
+// heatGradient - номера цветов 256-цветной палитры ANSI от самых близких (синий) до самых далёких (красный) клеток.
+var heatGradient = []int{21, 27, 33, 39, 45, 51, 50, 49, 48, 47, 46, 82, 118, 154, 190, 226, 220, 214, 208, 202, 196}
+
+// RenderHeatmap отображает лабиринт, окрашивая клетки из distances градиентом по их расстоянию,
+// в готовую для визуализации строку и возвращает её.
+func (r *expanderRenderer) RenderHeatmap(mz maze.Maze, distances map[cells.Coordinates]sutils.Cost) string {
+	expandedMaze := expandMaze(mz)
+
+	var maxDist sutils.Cost
+	for _, d := range distances {
+		maxDist = max(maxDist, d)
+	}
+
+	var result strings.Builder
+
+	margin := r.margin()
+
+	for y := margin; y < expandedMaze.Height-margin; y++ {
+		for x := margin; x < expandedMaze.Width-margin; x++ {
+			coords := cells.Coordinates{X: x, Y: y}
+
+			if d, ok := expandedDistance(expandedMaze, coords, distances); ok {
+				result.WriteString(fmt.Sprintf(heatCellFormat, heatColour(d, maxDist)))
+			} else {
+				result.WriteString(r.palette[expandedMaze.Cells[coords].Type])
+			}
+		}
+
+		result.WriteString("\n")
+	}
+
+	return result.String()
+}
+
+// expandedDistance возвращает расстояние до клетки расширенного лабиринта: для клетки исходного лабиринта - её
+// расстояние из distances, для ребра - среднее расстояние его концов; ok = false, если расстояние не определено.
+func expandedDistance(
+	expandedMaze maze.Maze,
+	coords cells.Coordinates,
+	distances map[cells.Coordinates]sutils.Cost,
+) (d sutils.Cost, ok bool) {
+	if original, isOriginal := shrink(coords); isOriginal {
+		d, ok = distances[original]
+		return d, ok
+	}
+
+	if expandedMaze.Cells[coords].Type != edge {
+		return 0, false
+	}
+
+	var sum, count sutils.Cost
+
+	for _, end := range expandedMaze.Cells[coords].Transitions {
+		original, _ := shrink(end)
+
+		endDist, endOk := distances[original]
+		if !endOk {
+			return 0, false
+		}
+
+		sum += endDist
+		count++
+	}
+
+	if count == 0 {
+		return 0, false
+	}
+
+	return sum / count, true
+}
+
+// heatColour возвращает цвет градиента для расстояния d при наибольшем расстоянии maxDist.
+func heatColour(d, maxDist sutils.Cost) int {
+	if maxDist == 0 {
+		return heatGradient[0]
+	}
+
+	return heatGradient[int(d)*(len(heatGradient)-1)/int(maxDist)]
+}
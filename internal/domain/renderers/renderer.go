@@ -5,25 +5,36 @@ import (
 
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/tremaux"
 )
 
 type renderer interface {
 	Render(mz maze.Maze) string                               // Отображает лабиринт в готовую для визуализации строку.
 	RenderPath(mz maze.Maze, path []cells.Coordinates) string // Отображает лабиринт и путь в готовую для визуализации строку.
+	// Отображает лабиринт, заполненные клетки и путь в готовую для визуализации строку.
+	RenderFilled(mz maze.Maze, path []cells.Coordinates, filled map[cells.Coordinates]struct{}) string
+	RenderTrace(mz maze.Maze, events []sutils.Event) string       // Отображает состояние поиска после событий events.
+	RenderPaths(mz maze.Maze, paths [][]cells.Coordinates) string // Отображает лабиринт и несколько путей разными цветами.
+	// Отображает лабиринт, окрашивая клетки градиентом по расстоянию до них.
+	RenderHeatmap(mz maze.Maze, distances map[cells.Coordinates]sutils.Cost) string
+	// Отображает лабиринт, путь и проходы, помеченные алгоритмом Тремо, в готовую для визуализации строку.
+	RenderMarks(mz maze.Maze, path []cells.Coordinates, marks map[tremaux.Passage]int) string
 }
 
-// New как фабрика возвращает конкретную реализацию renderers по строке, обозначающей желаемую реализацию, и палитре.
-func New(rendererType string) (renderer, error) {
+// New как фабрика возвращает конкретную реализацию renderers по строке, обозначающей желаемую реализацию;
+// при outerWall = true лабиринт выводится вместе с внешней стеной.
+func New(rendererType string, outerWall bool) (renderer, error) {
 	switch rendererType {
 	case "expander":
-		r, err := newExpanderRenderer()
+		r, err := newExpanderRenderer(outerWall)
 		if err != nil {
 			return nil, fmt.Errorf("can`t initialize expander renderers: %v", err)
 		}
 
 		return r, nil
 	default:
-		r, err := newExpanderRenderer()
+		r, err := newExpanderRenderer(outerWall)
 		if err != nil {
 			return nil, fmt.Errorf("can`t initialize expander renderers: %v", err)
 		}
@@ -1,15 +1,31 @@
 package renderers
 
-import "github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+import (
+	"slices"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+)
 
 // Значения универсальных вспомогательных типов стоит кодировать двухзначными отрицательными числами,
 // а значения вспомогательных типов конкретного рендерера - трёхзначными отрицательными.
 const (
-	Start cells.Type = -10 // Вспомогательный тип клетки, помечающий начальную клетки.
-	End   cells.Type = -20 // Вспомогательный тип клетки, помечающий конечную клетку.
-	Path  cells.Type = -30 // Вспомогательный тип клетки, помечающий остальную часть пути.
+	Start    cells.Type = -10 // Вспомогательный тип клетки, помечающий начальную клетки.
+	End      cells.Type = -20 // Вспомогательный тип клетки, помечающий конечную клетку.
+	Path     cells.Type = -30 // Вспомогательный тип клетки, помечающий остальную часть пути.
+	Filled   cells.Type = -40 // Вспомогательный тип клетки, помечающий заполненную клетку (например, тупик).
+	Frontier cells.Type = -50 // Вспомогательный тип клетки, помечающий клетку, ожидающую рассмотрения решателем.
+	Explored cells.Type = -60 // Вспомогательный тип клетки, помечающий клетку, раскрытую решателем.
+	// Вспомогательный тип клетки расширенного лабиринта, помечающий проход с одной меткой алгоритма Тремо.
+	MarkedOnce cells.Type = -80
+	// Вспомогательный тип клетки расширенного лабиринта, помечающий проход с двумя метками алгоритма Тремо.
+	MarkedTwice cells.Type = -90
 )
 
+// Alternatives - вспомогательные типы клеток, которыми в порядке возрастания стоимости окрашиваются альтернативные пути;
+// если путей больше, цвета повторяются.
+var Alternatives = []cells.Type{-70, -71, -72, -73, -74}
+
 // pathParts - множество типов клеток, обозначающих часть пути.
 var pathParts = map[cells.Type]struct{}{
 	Start: {},
@@ -17,5 +33,30 @@ var pathParts = map[cells.Type]struct{}{
 	Path:  {},
 }
 
+// overlays - множество типов клеток, обозначающих наложения, рёбра внутри которых окрашиваются так же, как клетки.
+var overlays = map[cells.Type]struct{}{
+	Filled:   {},
+	Frontier: {},
+	Explored: {},
+}
+
+// isOverlay возвращает true, если t - тип наложения, иначе false.
+func isOverlay(t cells.Type) bool {
+	_, ok := overlays[t]
+	return ok || slices.Contains(Alternatives, t)
+}
+
 // Palette - словарь {тип клетки: строчной визуализация}.
 type Palette map[cells.Type]string
+
+// clone возвращает копию лабиринта, чтобы наложение вспомогательных типов не изменяло исходный лабиринт.
+func clone(mz maze.Maze) maze.Maze {
+	cloned := maze.New(mz.Height, mz.Width)
+
+	for coords, cell := range mz.Cells {
+		cloned.Cells[coords].Type = cell.Type
+		cloned.Cells[coords].Transitions = append(cloned.Cells[coords].Transitions, cell.Transitions...)
+	}
+
+	return cloned
+}
@@ -0,0 +1,115 @@
+package astar
+
+import (
+	"math"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils"
+)
+
+// Solver - структура решателя по алгоритму A*.
+type Solver struct {
+	costs        sutils.CostModel                  // Модель стоимости путей.
+	heuristic    Heuristic                         // Эвристика, оценивающая количество шагов до конца.
+	dist         map[cells.Coordinates]sutils.Cost // Хранит для каждой достигнутой вершины лучшую известную оценку пути.
+	closed       map[cells.Coordinates]struct{}    // Хранит множество вершин, оценка пути до которых окончательна.
+	heap         sutils.Heap                       // Куча минимумов, содержащая вершины и их полную оценку.
+	predecessors sutils.Predecessors               // Хранит для каждой вершины информацию о её предшественниках.
+}
+
+// NewSolver возвращает указатель на инициализированный Solver с эвристикой heuristic
+// и стоимостью путей в модели costs.
+func NewSolver(costs sutils.CostModel, heuristic Heuristic) *Solver {
+	return &Solver{
+		costs:     costs,
+		heuristic: heuristic,
+		dist:      make(map[cells.Coordinates]sutils.Cost),
+		closed:    make(map[cells.Coordinates]struct{}),
+		heap:      sutils.New(),
+	}
+}
+
+// Solve находит путь от start до end в mz и возвращает его вместе со статистикой поиска.
+func (s *Solver) Solve(mz maze.Maze, start, end cells.Coordinates) (sutils.Result, error) {
+	err := sutils.Validate(mz, start, end)
+	if err != nil {
+		return sutils.Result{}, err
+	}
+
+	begin := time.Now()
+
+	s.prepare(mz.Height, mz.Width)
+
+	s.astar(mz, start, end)
+
+	return sutils.NewResult(s.costs, mz, sutils.RestorePath(start, end, s.predecessors), len(s.closed), time.Since(begin))
+}
+
+// astar находит кратчайший путь согласно алгоритму A*, записывая предшественника для каждой вершины
+// в predecessors для последующего восстановления пути.
+func (s *Solver) astar(mz maze.Maze, start, end cells.Coordinates) {
+	// Суть алгоритма A* (в текущей реализации):
+	//
+	// A* отличается от алгоритма Дейкстры тем, что вершины достаются из кучи в порядке полной оценки:
+	// оценки пути до вершины плюс эвристической оценки пути от неё до end. Эвристика умножается на
+	// нижнюю границу стоимости перехода: каждый шаг стоит не меньше неё, поэтому оценка не превышает
+	// настоящую стоимость, и найденный путь остаётся кратчайшим.
+	//
+	// Алгоритм:
+	// 1) Оценка пути до начальной вершины становится равной стоимости входа в неё, начало кладётся в кучу минимумов.
+	// 2) Достаётся вершина A с наименьшей полной оценкой; если она уже закрыта, она пропускается.
+	// 3) Вершина A закрывается; если она является end, алгоритм прерывает своё выполнение.
+	// 4) Для каждой смежной незакрытой вершины, оценку пути до которой удалось улучшить через A:
+	//   4.1) Обновляется оценка её пути.
+	//   4.2) Добавляется в кучу вместе с новой полной оценкой (устаревшие записи пропускаются в пункте 2).
+	//   4.3) Записывается координата вершины A.
+	//
+	// Пункты 2, 3, 4 повторяются, пока в куче существуют вершины, которые необходимо рассмотреть.
+	s.dist[start] = s.costs.Start(mz, start)
+	s.heap.Push(sutils.Item{Vertex: start, Weight: s.dist[start] + s.estimate(start, end)})
+
+	for s.heap.Len() != 0 {
+		vertex1 := s.heap.Pop().Vertex
+
+		if _, ok := s.closed[vertex1]; ok { // Устаревшая запись.
+			continue
+		}
+
+		s.closed[vertex1] = struct{}{}
+
+		if vertex1 == end {
+			break
+		}
+
+		for _, vertex2 := range mz.Cells[vertex1].Transitions {
+			if _, ok := s.closed[vertex2]; ok {
+				continue
+			}
+
+			newDist := s.dist[vertex1] + s.costs.Step(mz, vertex1, vertex2)
+
+			if oldDist, ok := s.dist[vertex2]; !ok || newDist < oldDist { // Если оценку пути удалось улучшить.
+				s.dist[vertex2] = newDist
+				s.heap.Push(sutils.Item{Vertex: vertex2, Weight: newDist + s.estimate(vertex2, end)})
+				s.predecessors[vertex2] = vertex1
+			}
+		}
+	}
+}
+
+// estimate возвращает эвристическую оценку пути от coords до end, масштабированную нижней границей стоимости перехода.
+func (s *Solver) estimate(coords, end cells.Coordinates) sutils.Cost {
+	// Округление вниз сохраняет допустимость эвристики.
+	return sutils.Cost(math.Floor(float64(s.costs.MinStep()) * s.heuristic(coords, end)))
+}
+
+// prepare подготавливает Solver для исполнения Solve.
+func (s *Solver) prepare(height, width int) {
+	clear(s.dist)
+	clear(s.closed)
+
+	s.heap = sutils.New()
+	s.predecessors = sutils.NewPredecessors(height, width)
+}
@@ -0,0 +1,144 @@
+package astar_test
+
+import (
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/cave"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/astar"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils/sutilstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAStarSolverSolve(t *testing.T) {
+	heuristics := map[string]astar.Heuristic{
+		"manhattan": astar.Manhattan,
+		"euclidean": astar.Euclidean,
+		"zero":      astar.Zero,
+	}
+
+	type args struct {
+		mz    maze.Maze
+		start cells.Coordinates
+		end   cells.Coordinates
+	}
+
+	tests := []struct {
+		name        string
+		args        args
+		expected    []cells.Coordinates
+		expectedErr error
+	}{
+		{
+			name: "path doesn`t exist",
+			args: args{
+				mz:    sutilstest.NewUnlinkedMaze(3, 3),
+				start: cells.Coordinates{X: 0, Y: 0},
+				end:   cells.Coordinates{X: 2, Y: 2},
+			},
+			expectedErr: sutils.ErrUnreachable,
+		},
+		{
+			name: "path is the cheapest of several",
+			args: args{
+				mz:    newSeveralPathMaze(),
+				start: cells.Coordinates{X: 0, Y: 0},
+				end:   cells.Coordinates{X: 2, Y: 2},
+			},
+			expected: []cells.Coordinates{
+				{X: 0, Y: 0},
+				{X: 1, Y: 0},
+				{X: 2, Y: 0},
+				{X: 2, Y: 1},
+				{X: 2, Y: 2},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		for name, heuristic := range heuristics {
+			t.Run(tt.name+" ("+name+")", func(t *testing.T) {
+				s := astar.NewSolver(sutils.DefaultCosts(), heuristic)
+
+				result, err := s.Solve(tt.args.mz, tt.args.start, tt.args.end)
+
+				if tt.expectedErr != nil {
+					assert.ErrorIs(t, err, tt.expectedErr)
+					return
+				}
+
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result.Path)
+			})
+		}
+	}
+}
+
+func TestAStarSolverSolveOnCave(t *testing.T) {
+	mz, err := cave.NewGenerator().Generate(48, 48)
+	require.NoError(t, err)
+
+	var passages []cells.Coordinates
+
+	for coords, cell := range mz.Cells {
+		if cell.Type != cells.Wall {
+			passages = append(passages, coords)
+		}
+	}
+
+	start, end := passages[0], passages[len(passages)-1]
+
+	// Эвристика Zero превращает A* в алгоритм Дейкстры, поэтому её результат - эталонная стоимость.
+	expected, err := astar.NewSolver(sutils.DefaultCosts(), astar.Zero).Solve(mz, start, end)
+	require.NoError(t, err)
+
+	for _, heuristic := range []astar.Heuristic{astar.Manhattan, astar.Euclidean} {
+		result, err := astar.NewSolver(sutils.DefaultCosts(), heuristic).Solve(mz, start, end)
+		require.NoError(t, err)
+
+		assert.Equal(t, expected.Cost, result.Cost)
+	}
+}
+
+func newSeveralPathMaze() maze.Maze {
+	mz := maze.New(3, 3)
+
+	link := func(t cells.Type, coords ...cells.Coordinates) {
+		for i, c := range coords {
+			if i > 0 && i < len(coords)-1 {
+				mz.Cells[c].Type = t
+			}
+
+			if i > 0 {
+				mz.Cells[coords[i-1]].Transitions = append(mz.Cells[coords[i-1]].Transitions, c)
+				mz.Cells[c].Transitions = append(mz.Cells[c].Transitions, coords[i-1])
+			}
+		}
+	}
+
+	mz.Cells[cells.Coordinates{X: 0, Y: 0}].Type = cells.Pass
+	mz.Cells[cells.Coordinates{X: 2, Y: 2}].Type = cells.LightedPass
+
+	// Первый путь, вес которого будет равен 2 + 2 + 2 + 2 + 1 = 9.
+	link(cells.Pass,
+		cells.Coordinates{X: 0, Y: 0},
+		cells.Coordinates{X: 0, Y: 1},
+		cells.Coordinates{X: 0, Y: 2},
+		cells.Coordinates{X: 1, Y: 2},
+		cells.Coordinates{X: 2, Y: 2},
+	)
+
+	// Второй путь, вес которого будет равен 2 + 1 + 1 + 1 + 1 = 6.
+	link(cells.LightedPass,
+		cells.Coordinates{X: 0, Y: 0},
+		cells.Coordinates{X: 1, Y: 0},
+		cells.Coordinates{X: 2, Y: 0},
+		cells.Coordinates{X: 2, Y: 1},
+		cells.Coordinates{X: 2, Y: 2},
+	)
+
+	return mz
+}
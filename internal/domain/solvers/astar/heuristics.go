@@ -0,0 +1,25 @@
+package astar
+
+import (
+	"math"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+)
+
+// Heuristic оценивает расстояние в шагах от from до to, не превышая настоящего количества шагов.
+type Heuristic func(from, to cells.Coordinates) float64
+
+// Manhattan возвращает манхэттенское расстояние между from и to.
+func Manhattan(from, to cells.Coordinates) float64 {
+	return math.Abs(float64(from.X-to.X)) + math.Abs(float64(from.Y-to.Y))
+}
+
+// Euclidean возвращает евклидово расстояние между from и to.
+func Euclidean(from, to cells.Coordinates) float64 {
+	return math.Hypot(float64(from.X-to.X), float64(from.Y-to.Y))
+}
+
+// Zero всегда возвращает 0, превращая A* в алгоритм Дейкстры.
+func Zero(_, _ cells.Coordinates) float64 {
+	return 0
+}
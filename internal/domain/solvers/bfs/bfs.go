@@ -0,0 +1,72 @@
+package bfs
+
+import (
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils"
+)
+
+// Solver - структура решателя по поиску в ширину (BFS).
+type Solver struct {
+	costs        sutils.CostModel               // Модель стоимости путей.
+	visited      map[cells.Coordinates]struct{} // Хранит множество посещённых вершин.
+	predecessors sutils.Predecessors            // Хранит для каждой вершины информацию о её предшественниках.
+}
+
+// NewSolver возвращает указатель на инициализированный Solver со стоимостью путей в модели costs.
+func NewSolver(costs sutils.CostModel) *Solver {
+	return &Solver{
+		costs:   costs,
+		visited: make(map[cells.Coordinates]struct{}),
+	}
+}
+
+// Solve находит путь от start до end в mz с наименьшим количеством клеток и возвращает его вместе со статистикой поиска.
+func (s *Solver) Solve(mz maze.Maze, start, end cells.Coordinates) (sutils.Result, error) {
+	err := sutils.Validate(mz, start, end)
+	if err != nil {
+		return sutils.Result{}, err
+	}
+
+	begin := time.Now()
+
+	s.prepare(mz.Height, mz.Width)
+
+	s.bfs(mz, start, end)
+
+	return sutils.NewResult(s.costs, mz, sutils.RestorePath(start, end, s.predecessors), len(s.visited), time.Since(begin))
+}
+
+// bfs находит путь с наименьшим количеством шагов, записывая предшественника для каждой вершины.
+func (s *Solver) bfs(mz maze.Maze, start, end cells.Coordinates) {
+	// Поиск в ширину игнорирует типы клеток: вершины рассматриваются в порядке удалённости от start
+	// в шагах, поэтому первый же найденный путь до end содержит наименьшее количество клеток.
+	queue := []cells.Coordinates{start}
+	s.visited[start] = struct{}{}
+
+	for len(queue) != 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if current == end {
+			break
+		}
+
+		for _, next := range mz.Cells[current].Transitions {
+			if _, ok := s.visited[next]; !ok {
+				s.visited[next] = struct{}{}
+				s.predecessors[next] = current // Записываем предшественника для next.
+				queue = append(queue, next)
+			}
+		}
+	}
+}
+
+// prepare подготавливает Solver для исполнения Solve.
+func (s *Solver) prepare(height, width int) {
+	clear(s.visited)
+
+	s.predecessors = sutils.NewPredecessors(height, width)
+}
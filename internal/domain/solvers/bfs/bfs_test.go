@@ -0,0 +1,110 @@
+package bfs_test
+
+import (
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/bfs"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils/sutilstest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBFSSolverSolve(t *testing.T) {
+	type args struct {
+		mz    maze.Maze
+		start cells.Coordinates
+		end   cells.Coordinates
+	}
+
+	tests := []struct {
+		name        string
+		args        args
+		expected    []cells.Coordinates
+		expectedErr error
+	}{
+		{
+			name: "path doesn`t exist",
+			args: args{
+				mz:    sutilstest.NewUnlinkedMaze(3, 3),
+				start: cells.Coordinates{X: 0, Y: 0},
+				end:   cells.Coordinates{X: 2, Y: 2},
+			},
+			expectedErr: sutils.ErrUnreachable,
+		},
+		{
+			name: "path to self",
+			args: args{
+				mz:    sutilstest.NewUnlinkedMaze(3, 3),
+				start: cells.Coordinates{X: 0, Y: 0},
+				end:   cells.Coordinates{X: 0, Y: 0},
+			},
+			expected: []cells.Coordinates{{X: 0, Y: 0}},
+		},
+		{
+			name: "path with the fewest cells ignores terrain",
+			args: args{
+				mz:    newDetourMaze(),
+				start: cells.Coordinates{X: 0, Y: 0},
+				end:   cells.Coordinates{X: 0, Y: 4},
+			},
+			expected: []cells.Coordinates{
+				{X: 0, Y: 0},
+				{X: 0, Y: 1},
+				{X: 0, Y: 2},
+				{X: 0, Y: 3},
+				{X: 0, Y: 4},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := bfs.NewSolver(sutils.DefaultCosts())
+
+			result, err := s.Solve(tt.args.mz, tt.args.start, tt.args.end)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result.Path)
+		})
+	}
+}
+
+// newDetourMaze возвращает лабиринт, в котором короткий путь по столбцу x = 0 имеет вес 8,
+// а более длинный обход по столбцу x = 1 - вес 7.
+func newDetourMaze() maze.Maze {
+	mz := maze.New(5, 2)
+
+	link := func(coords ...cells.Coordinates) {
+		for i := 1; i < len(coords); i++ {
+			mz.Cells[coords[i-1]].Transitions = append(mz.Cells[coords[i-1]].Transitions, coords[i])
+			mz.Cells[coords[i]].Transitions = append(mz.Cells[coords[i]].Transitions, coords[i-1])
+		}
+	}
+
+	for coords, cell := range mz.Cells {
+		if coords.X == 0 && coords.Y != 0 && coords.Y != 4 {
+			cell.Type = cells.Pass
+		} else {
+			cell.Type = cells.LightedPass
+		}
+	}
+
+	var direct, detour []cells.Coordinates
+
+	for y := range 5 {
+		direct = append(direct, cells.Coordinates{X: 0, Y: y})
+		detour = append(detour, cells.Coordinates{X: 1, Y: y})
+	}
+
+	link(direct...)
+	link(append(append([]cells.Coordinates{direct[0]}, detour...), direct[4])...)
+
+	return mz
+}
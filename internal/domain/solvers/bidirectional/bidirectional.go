@@ -0,0 +1,205 @@
+package bidirectional
+
+import (
+	"math"
+	"slices"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/astar"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils"
+)
+
+// frontier хранит состояние поиска в одном направлении.
+type frontier struct {
+	dist         map[cells.Coordinates]sutils.Cost // Хранит для каждой достигнутой вершины лучшую известную оценку пути.
+	closed       map[cells.Coordinates]struct{}    // Хранит множество вершин, оценка пути до которых окончательна.
+	heap         sutils.Heap                       // Куча минимумов, содержащая вершины и их ключи.
+	predecessors sutils.Predecessors               // Хранит для каждой вершины предыдущую в направлении поиска.
+	forward      bool                              // true, если поиск идёт от начала, false - если от конца.
+}
+
+// Solver - структура решателя по двунаправленному алгоритму Дейкстры (или A*).
+type Solver struct {
+	costs    sutils.CostModel // Модель стоимости путей.
+	guided   bool             // Если true, поиски направляются усреднённой манхэттенской эвристикой.
+	forward  frontier         // Поиск от начала.
+	backward frontier         // Поиск от конца.
+	best     sutils.Cost      // Стоимость лучшего найденного пути без учёта стоимости входа в начальную клетку.
+	meetFrom cells.Coordinates
+	meetTo   cells.Coordinates
+	start    cells.Coordinates
+	end      cells.Coordinates
+}
+
+// NewSolver возвращает указатель на инициализированный Solver со стоимостью путей в модели costs;
+// при guided = true поиски направляются эвристикой.
+func NewSolver(costs sutils.CostModel, guided bool) *Solver {
+	return &Solver{
+		costs:    costs,
+		guided:   guided,
+		forward:  newFrontier(true),
+		backward: newFrontier(false),
+	}
+}
+
+// newFrontier возвращает инициализированный frontier.
+func newFrontier(forward bool) frontier {
+	return frontier{
+		dist:    make(map[cells.Coordinates]sutils.Cost),
+		closed:  make(map[cells.Coordinates]struct{}),
+		heap:    sutils.New(),
+		forward: forward,
+	}
+}
+
+// Solve находит путь от start до end в mz и возвращает его вместе со статистикой поиска.
+func (s *Solver) Solve(mz maze.Maze, start, end cells.Coordinates) (sutils.Result, error) {
+	err := sutils.Validate(mz, start, end)
+	if err != nil {
+		return sutils.Result{}, err
+	}
+
+	begin := time.Now()
+
+	if start == end {
+		return sutils.NewResult(s.costs, mz, []cells.Coordinates{start}, 1, time.Since(begin))
+	}
+
+	s.prepare(mz.Height, mz.Width, start, end)
+
+	s.bidirectional(mz)
+
+	path := []cells.Coordinates{}
+	if s.best != math.MaxInt {
+		path = s.restorePath()
+	}
+
+	return sutils.NewResult(s.costs, mz, path, len(s.forward.closed)+len(s.backward.closed), time.Since(begin))
+}
+
+// bidirectional находит кратчайший путь, одновременно ведя поиск от начала и от конца.
+func (s *Solver) bidirectional(mz maze.Maze) {
+	// Суть двунаправленного поиска (в текущей реализации):
+	//
+	// Поиск от начала оценивает стоимость пути от start до вершины, поиск от конца - стоимость пути от вершины
+	// до end, проходя переходы в обратную сторону, но оплачивая их в исходном направлении.
+	//
+	// Алгоритм:
+	// 1) Начало и конец кладутся в кучи своих поисков с нулевой оценкой.
+	// 2) На каждом шаге поиск, минимальный ключ кучи которого меньше, раскрывает одну вершину, как алгоритм Дейкстры.
+	// 3) При рассмотрении перехода в вершину, уже достигнутую другим поиском, обновляется вес лучшего пути best.
+	// 4) Поиск останавливается, как только сумма минимальных ключей обеих куч не меньше best:
+	//    ни один ещё не найденный путь не может быть дешевле, поэтому это условие корректно и для весов.
+	//
+	// При guided = true ключи сдвигаются на усреднённый потенциал p(v) = (h(v, end) - h(start, v)) / 2,
+	// где h - манхэттенское расстояние, умноженное на нижнюю границу стоимости перехода. Потенциал согласован,
+	// приведённые веса переходов неотрицательны, и условие остановки сохраняет вид из пункта 4.
+	// Чтобы все ключи оставались целыми, и ключи, и best хранятся удвоенными.
+	s.forward.push(s.start, 0, s.potential(s.start))
+	s.backward.push(s.end, 0, -s.potential(s.end))
+
+	for s.forward.heap.Len() != 0 && s.backward.heap.Len() != 0 {
+		forwardKey, backwardKey := s.forward.heap.Peek().Weight, s.backward.heap.Peek().Weight
+
+		if s.best != math.MaxInt && forwardKey+backwardKey >= 2*s.best {
+			break
+		}
+
+		if forwardKey <= backwardKey {
+			s.expand(mz, &s.forward, &s.backward)
+		} else {
+			s.expand(mz, &s.backward, &s.forward)
+		}
+	}
+}
+
+// expand раскрывает вершину с наименьшим ключом поиска current, обновляя лучший путь по данным поиска other.
+func (s *Solver) expand(mz maze.Maze, current, other *frontier) {
+	vertex1 := current.heap.Pop().Vertex
+
+	if _, ok := current.closed[vertex1]; ok { // Устаревшая запись.
+		return
+	}
+
+	current.closed[vertex1] = struct{}{}
+
+	for _, vertex2 := range mz.Cells[vertex1].Transitions {
+		weight := s.costs.Step(mz, vertex2, vertex1) // Поиск от конца проходит переход vertex2 -> vertex1.
+		if current.forward {
+			weight = s.costs.Step(mz, vertex1, vertex2)
+		}
+
+		newDist := current.dist[vertex1] + weight
+
+		if oldDist, ok := current.dist[vertex2]; !ok || newDist < oldDist {
+			sign := sutils.Cost(1)
+			if !current.forward {
+				sign = -1
+			}
+
+			current.predecessors[vertex2] = vertex1
+			current.push(vertex2, newDist, sign*s.potential(vertex2))
+		}
+
+		if otherDist, ok := other.dist[vertex2]; ok && newDist+otherDist < s.best { // Поиски встретились.
+			s.best = newDist + otherDist
+
+			if current.forward {
+				s.meetFrom, s.meetTo = vertex1, vertex2
+			} else {
+				s.meetFrom, s.meetTo = vertex2, vertex1
+			}
+		}
+	}
+}
+
+// push записывает оценку пути до вершины и кладёт её в кучу с удвоенным ключом, сдвинутым на потенциал.
+func (f *frontier) push(vertex cells.Coordinates, dist, doubledPotential sutils.Cost) {
+	f.dist[vertex] = dist
+	f.heap.Push(sutils.Item{Vertex: vertex, Weight: 2*dist + doubledPotential})
+}
+
+// potential возвращает удвоенный усреднённый потенциал вершины.
+func (s *Solver) potential(coords cells.Coordinates) sutils.Cost {
+	if !s.guided {
+		return 0
+	}
+
+	return s.costs.MinStep() * sutils.Cost(astar.Manhattan(coords, s.end)-astar.Manhattan(s.start, coords))
+}
+
+// restorePath восстанавливает путь по предшественникам обоих поисков через точку их встречи.
+func (s *Solver) restorePath() []cells.Coordinates {
+	var path []cells.Coordinates
+
+	for current := s.meetFrom; current != s.start; current = s.forward.predecessors[current] {
+		path = append(path, current)
+	}
+
+	path = append(path, s.start)
+	slices.Reverse(path)
+
+	for current := s.meetTo; ; current = s.backward.predecessors[current] {
+		path = append(path, current)
+
+		if current == s.end {
+			return path
+		}
+	}
+}
+
+// prepare подготавливает Solver для исполнения Solve.
+func (s *Solver) prepare(height, width int, start, end cells.Coordinates) {
+	for _, f := range []*frontier{&s.forward, &s.backward} {
+		clear(f.dist)
+		clear(f.closed)
+
+		f.heap = sutils.New()
+		f.predecessors = sutils.NewPredecessors(height, width)
+	}
+
+	s.best = math.MaxInt
+	s.start, s.end = start, end
+}
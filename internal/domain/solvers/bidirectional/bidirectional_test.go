@@ -0,0 +1,153 @@
+package bidirectional_test
+
+import (
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/cave"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/prim"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/astar"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/bfs"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/bidirectional"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils/sutilstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBidirectionalSolverSolve(t *testing.T) {
+	adjacent := maze.New(1, 2)
+	adjacent.Cells[cells.Coordinates{X: 0, Y: 0}] = &cells.Cell{
+		Type:        cells.Pass,
+		Transitions: []cells.Coordinates{{X: 1, Y: 0}},
+	}
+	adjacent.Cells[cells.Coordinates{X: 1, Y: 0}] = &cells.Cell{
+		Type:        cells.Pass,
+		Transitions: []cells.Coordinates{{X: 0, Y: 0}},
+	}
+
+	type args struct {
+		mz    maze.Maze
+		start cells.Coordinates
+		end   cells.Coordinates
+	}
+
+	unlinked := maze.New(3, 3)
+	for _, cell := range unlinked.Cells {
+		cell.Type = cells.Pass
+	}
+
+	tests := []struct {
+		name        string
+		args        args
+		expected    []cells.Coordinates
+		expectedErr error
+	}{
+		{
+			name: "path doesn`t exist",
+			args: args{
+				mz:    unlinked,
+				start: cells.Coordinates{X: 0, Y: 0},
+				end:   cells.Coordinates{X: 2, Y: 2},
+			},
+			expectedErr: sutils.ErrUnreachable,
+		},
+		{
+			name: "path to self",
+			args: args{
+				mz:    unlinked,
+				start: cells.Coordinates{X: 0, Y: 0},
+				end:   cells.Coordinates{X: 0, Y: 0},
+			},
+			expected: []cells.Coordinates{{X: 0, Y: 0}},
+		},
+		{
+			name: "start is a wall",
+			args: args{
+				mz:    maze.New(3, 3),
+				start: cells.Coordinates{X: 0, Y: 0},
+				end:   cells.Coordinates{X: 2, Y: 2},
+			},
+			expectedErr: sutils.ErrMaskedCell,
+		},
+		{
+			name: "start and end are adjacent",
+			args: args{
+				mz:    adjacent,
+				start: cells.Coordinates{X: 0, Y: 0},
+				end:   cells.Coordinates{X: 1, Y: 0},
+			},
+			expected: []cells.Coordinates{{X: 0, Y: 0}, {X: 1, Y: 0}},
+		},
+	}
+
+	for _, tt := range tests {
+		for _, guided := range []bool{false, true} {
+			t.Run(tt.name, func(t *testing.T) {
+				s := bidirectional.NewSolver(sutils.DefaultCosts(), guided)
+
+				result, err := s.Solve(tt.args.mz, tt.args.start, tt.args.end)
+
+				if tt.expectedErr != nil {
+					assert.ErrorIs(t, err, tt.expectedErr)
+					return
+				}
+
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result.Path)
+			})
+		}
+	}
+}
+
+func TestBidirectionalSolverSolveOnPerfectMaze(t *testing.T) {
+	mz, err := prim.NewGenerator().Generate(32, 32)
+	require.NoError(t, err)
+
+	start, end := cells.Coordinates{X: 0, Y: 0}, cells.Coordinates{X: 31, Y: 31}
+
+	// В идеальном лабиринте путь единственен, поэтому он совпадает с найденным поиском в ширину.
+	expected, err := bfs.NewSolver(sutils.DefaultCosts()).Solve(mz, start, end)
+	require.NoError(t, err)
+
+	for _, guided := range []bool{false, true} {
+		result, err := bidirectional.NewSolver(sutils.DefaultCosts(), guided).Solve(mz, start, end)
+		require.NoError(t, err)
+
+		assert.Equal(t, expected.Path, result.Path)
+	}
+}
+
+func TestBidirectionalSolverSolveOnCave(t *testing.T) {
+	mz, err := cave.NewGenerator().Generate(40, 40)
+	require.NoError(t, err)
+
+	var passages []cells.Coordinates
+
+	for coords, cell := range mz.Cells {
+		if cell.Type != cells.Wall {
+			passages = append(passages, coords)
+		}
+	}
+
+	reference := astar.NewSolver(sutils.DefaultCosts(), astar.Zero)
+	solvers := []*bidirectional.Solver{
+		bidirectional.NewSolver(sutils.DefaultCosts(), false),
+		bidirectional.NewSolver(sutils.DefaultCosts(), true),
+	}
+
+	for i := 0; i+1 < len(passages) && i < 100; i += 2 {
+		start, end := passages[i], passages[i+1]
+		expected, err := reference.Solve(mz, start, end)
+		require.NoError(t, err)
+
+		for _, s := range solvers {
+			result, err := s.Solve(mz, start, end)
+			require.NoError(t, err)
+
+			assert.Equal(t, expected.Cost, result.Cost)
+			assert.True(t, sutilstest.IsPathValid(mz, result.Path, start, end))
+		}
+	}
+}
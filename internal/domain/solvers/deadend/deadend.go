@@ -0,0 +1,138 @@
+package deadend
+
+import (
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils"
+)
+
+// Filling содержит результат заполнения тупиков.
+type Filling struct {
+	Path   []cells.Coordinates            // Путь от начала до конца по незаполненным клеткам; пуст, если его нет.
+	Filled map[cells.Coordinates]struct{} // Множество заполненных клеток.
+}
+
+// Solver - структура решателя по заполнению тупиков.
+type Solver struct {
+	costs        sutils.CostModel          // Модель стоимости путей.
+	degrees      map[cells.Coordinates]int // Хранит для каждой незаполненной клетки количество незаполненных соседей.
+	predecessors sutils.Predecessors       // Хранит для каждой вершины информацию о её предшественниках.
+}
+
+// NewSolver возвращает указатель на инициализированный Solver со стоимостью путей в модели costs.
+func NewSolver(costs sutils.CostModel) *Solver {
+	return &Solver{
+		costs:   costs,
+		degrees: make(map[cells.Coordinates]int),
+	}
+}
+
+// Solve находит путь от start до end в mz и возвращает его вместе со статистикой поиска.
+func (s *Solver) Solve(mz maze.Maze, start, end cells.Coordinates) (sutils.Result, error) {
+	result, _, err := s.SolveFilled(mz, start, end)
+
+	return result, err
+}
+
+// SolveFilled работает как Solve, дополнительно возвращая заполнение тупиков, по которому найден путь.
+func (s *Solver) SolveFilled(mz maze.Maze, start, end cells.Coordinates) (sutils.Result, Filling, error) {
+	err := sutils.Validate(mz, start, end)
+	if err != nil {
+		return sutils.Result{}, Filling{}, err
+	}
+
+	begin := time.Now()
+
+	filling := s.Fill(mz, start, end)
+
+	// Метод обрабатывает весь лабиринт: исследованы все заполненные и оставшиеся незаполненными проходы.
+	result, err := sutils.NewResult(s.costs, mz, filling.Path, len(filling.Filled)+len(s.degrees), time.Since(begin))
+
+	return result, filling, err
+}
+
+// Fill заполняет тупики mz и возвращает заполненные клетки и оставшийся путь от start до end.
+func (s *Solver) Fill(mz maze.Maze, start, end cells.Coordinates) Filling {
+	// Суть заполнения тупиков:
+	//
+	// Это не поиск, а метод, обрабатывающий весь лабиринт сразу.
+	//
+	// Алгоритм:
+	// 1) Находятся все тупики - проходы, у которых не больше одного незаполненного соседа (кроме start и end).
+	// 2) Тупик заполняется, и его сосед, если сам стал тупиком, тоже попадает в очередь на заполнение.
+	// 3) Пункт 2 повторяется, пока очередь не опустеет.
+	//
+	// В идеальном лабиринте незаполненным остаётся ровно путь от start до end; в лабиринте с циклами
+	// остаются ещё и циклы, поэтому путь восстанавливается поиском в ширину по незаполненным клеткам.
+	s.prepare(mz)
+
+	filled := make(map[cells.Coordinates]struct{})
+	queue := make([]cells.Coordinates, 0)
+
+	for coords, degree := range s.degrees {
+		if degree <= 1 && coords != start && coords != end {
+			queue = append(queue, coords)
+		}
+	}
+
+	for len(queue) != 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		filled[current] = struct{}{}
+		delete(s.degrees, current)
+
+		for _, next := range mz.Cells[current].Transitions {
+			if _, ok := s.degrees[next]; !ok { // Сосед уже заполнен.
+				continue
+			}
+
+			s.degrees[next]--
+
+			if s.degrees[next] == 1 && next != start && next != end {
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	return Filling{Path: s.restorePath(mz, start, end), Filled: filled}
+}
+
+// restorePath находит путь от start до end поиском в ширину по незаполненным клеткам.
+func (s *Solver) restorePath(mz maze.Maze, start, end cells.Coordinates) []cells.Coordinates {
+	visited := map[cells.Coordinates]struct{}{start: {}}
+	queue := []cells.Coordinates{start}
+
+	for len(queue) != 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, next := range mz.Cells[current].Transitions {
+			_, isVisited := visited[next]
+			_, isOpen := s.degrees[next]
+
+			if !isVisited && isOpen {
+				visited[next] = struct{}{}
+				s.predecessors[next] = current
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	return sutils.RestorePath(start, end, s.predecessors)
+}
+
+// prepare подготавливает Solver для исполнения Fill.
+func (s *Solver) prepare(mz maze.Maze) {
+	clear(s.degrees)
+
+	for coords, cell := range mz.Cells {
+		if cell.Type != cells.Wall {
+			s.degrees[coords] = len(cell.Transitions)
+		}
+	}
+
+	s.predecessors = sutils.NewPredecessors(mz.Height, mz.Width)
+}
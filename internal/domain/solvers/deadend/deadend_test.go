@@ -0,0 +1,84 @@
+package deadend_test
+
+import (
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/prim"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/bfs"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/deadend"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeadEndSolverFillOnPerfectMaze(t *testing.T) {
+	mz, err := prim.NewGenerator().Generate(24, 24)
+	require.NoError(t, err)
+
+	start, end := cells.Coordinates{X: 0, Y: 0}, cells.Coordinates{X: 23, Y: 23}
+
+	filling := deadend.NewSolver(sutils.DefaultCosts()).Fill(mz, start, end)
+
+	expected, err := bfs.NewSolver(sutils.DefaultCosts()).Solve(mz, start, end)
+	require.NoError(t, err)
+
+	// В идеальном лабиринте незаполненным остаётся ровно единственный путь.
+	assert.Equal(t, expected.Path, filling.Path)
+	assert.Len(t, filling.Filled, len(mz.Cells)-len(filling.Path))
+
+	for _, coords := range filling.Path {
+		assert.NotContains(t, filling.Filled, coords)
+	}
+}
+
+func TestDeadEndSolverFillWithCycle(t *testing.T) {
+	// Кольцо 2x2, к которому присоединены конец и тупиковый отросток из двух клеток.
+	mz := maze.New(3, 3)
+	link := func(a, b cells.Coordinates) {
+		mz.Cells[a].Transitions = append(mz.Cells[a].Transitions, b)
+		mz.Cells[b].Transitions = append(mz.Cells[b].Transitions, a)
+	}
+
+	for _, cell := range mz.Cells {
+		cell.Type = cells.Pass
+	}
+
+	link(cells.Coordinates{X: 0, Y: 0}, cells.Coordinates{X: 1, Y: 0})
+	link(cells.Coordinates{X: 1, Y: 0}, cells.Coordinates{X: 1, Y: 1})
+	link(cells.Coordinates{X: 1, Y: 1}, cells.Coordinates{X: 0, Y: 1})
+	link(cells.Coordinates{X: 0, Y: 1}, cells.Coordinates{X: 0, Y: 0})
+	link(cells.Coordinates{X: 1, Y: 1}, cells.Coordinates{X: 2, Y: 1})
+	link(cells.Coordinates{X: 0, Y: 1}, cells.Coordinates{X: 0, Y: 2})
+	link(cells.Coordinates{X: 0, Y: 2}, cells.Coordinates{X: 1, Y: 2})
+
+	filling := deadend.NewSolver(sutils.DefaultCosts()).Fill(mz, cells.Coordinates{X: 0, Y: 0}, cells.Coordinates{X: 2, Y: 1})
+
+	assert.Equal(t, map[cells.Coordinates]struct{}{
+		{X: 0, Y: 2}: {},
+		{X: 1, Y: 2}: {},
+		{X: 2, Y: 0}: {},
+		{X: 2, Y: 2}: {},
+	}, filling.Filled)
+	assert.Len(t, filling.Path, 4)
+	assert.Equal(t, cells.Coordinates{X: 2, Y: 1}, filling.Path[3])
+}
+
+func TestDeadEndSolverSolveFilled(t *testing.T) {
+	mz, err := prim.NewGenerator().Generate(16, 16)
+	require.NoError(t, err)
+
+	start, end := cells.Coordinates{X: 0, Y: 0}, cells.Coordinates{X: 15, Y: 15}
+
+	result, filling, err := deadend.NewSolver(sutils.DefaultCosts()).SolveFilled(mz, start, end)
+	require.NoError(t, err)
+
+	// Результат описывает то же заполнение, что возвращено вместе с ним.
+	assert.Equal(t, filling.Path, result.Path)
+	assert.Equal(t, len(mz.Cells), result.Explored)
+	assert.Len(t, filling.Filled, len(mz.Cells)-len(filling.Path))
+
+	_, _, err = deadend.NewSolver(sutils.DefaultCosts()).SolveFilled(mz, start, cells.Coordinates{X: 16, Y: 0})
+	assert.ErrorIs(t, err, sutils.ErrOutOfBounds)
+}
@@ -1,6 +1,8 @@
 package dfs
 
 import (
+	"time"
+
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils"
@@ -8,25 +10,44 @@ import (
 
 // Solver - структура решателя по модифицированному поиску в глубину (DFS).
 type Solver struct {
+	costs        sutils.CostModel               // Модель стоимости путей.
 	visited      map[cells.Coordinates]struct{} // Хранит множество посещённых вершин.
 	predecessors sutils.Predecessors            // Хранит для каждой вершины информацию о её предшественниках.
+	tracer       sutils.Tracer                  // Получает события поиска; nil, если трассировка не нужна.
 	mz           maze.Maze
 }
 
-// NewSolver возвращает указатель на инициализированный Solver.
-func NewSolver() *Solver {
+// NewSolver возвращает указатель на инициализированный Solver со стоимостью путей в модели costs.
+func NewSolver(costs sutils.CostModel) *Solver {
 	return &Solver{
+		costs:   costs,
 		visited: make(map[cells.Coordinates]struct{}),
 	}
 }
 
-// Solve находит и возвращает путь от start до end в mz в виде []cells.Coordinates.
-func (s *Solver) Solve(mz maze.Maze, start, end cells.Coordinates) []cells.Coordinates {
-	s.prepare(mz)
+// Solve находит путь от start до end в mz и возвращает его вместе со статистикой поиска.
+func (s *Solver) Solve(mz maze.Maze, start, end cells.Coordinates) (sutils.Result, error) {
+	return s.SolveTraced(mz, start, end, nil)
+}
+
+// SolveTraced работает как Solve, дополнительно передавая tracer события поиска в порядке их возникновения.
+func (s *Solver) SolveTraced(mz maze.Maze, start, end cells.Coordinates, tracer sutils.Tracer) (sutils.Result, error) {
+	err := sutils.Validate(mz, start, end)
+	if err != nil {
+		return sutils.Result{}, err
+	}
+
+	begin := time.Now()
+
+	s.prepare(mz, tracer)
 
+	sutils.Trace(s.tracer, sutils.Event{Kind: sutils.Pushed, Vertex: start})
 	s.dfs(start, cells.Coordinates{X: sutils.MissingX, Y: sutils.MissingY}, end)
 
-	return sutils.RestorePath(start, end, s.predecessors)
+	path := sutils.RestorePath(start, end, s.predecessors)
+	sutils.TracePath(s.tracer, path)
+
+	return sutils.NewResult(s.costs, mz, path, len(s.visited), time.Since(begin))
 }
 
 // dfs находит путь с помощью поиска в глубину, записывая предшественника для каждой вершины.
@@ -36,10 +57,17 @@ func (s *Solver) dfs(current, previous, end cells.Coordinates) {
 	//
 	// В некотором смысле, алгоритм пытается "здесь и сейчас" избежать обычного прохода cells.Pass и
 	// вместо него сначала пойти в более освещённый cells.LightedPass; хотя в действительности
-	// он не мыслит такими категориями, не ограничаясь только данными типами клеток, а рассуждая в плоскости их весов.
+	// он не мыслит такими категориями, не ограничаясь только данными типами клеток, а рассуждая в плоскости
+	// стоимостей переходов модели стоимости.
 	s.predecessors[current] = previous
 	s.visited[current] = struct{}{}
 
+	if previous.X != sutils.MissingX {
+		sutils.Trace(s.tracer, sutils.Event{Kind: sutils.PredecessorSet, Vertex: current, Predecessor: previous})
+	}
+
+	sutils.Trace(s.tracer, sutils.Event{Kind: sutils.Popped, Vertex: current})
+
 	if current == end {
 		return
 	}
@@ -50,8 +78,10 @@ func (s *Solver) dfs(current, previous, end cells.Coordinates) {
 		if _, ok := s.visited[next]; !ok {
 			localHeap.Push(sutils.Item{
 				Vertex: next,
-				Weight: s.mz.Cells[next].Type,
+				Weight: s.costs.Step(s.mz, current, next),
 			})
+
+			sutils.Trace(s.tracer, sutils.Event{Kind: sutils.Pushed, Vertex: next})
 		}
 	}
 
@@ -61,7 +91,10 @@ func (s *Solver) dfs(current, previous, end cells.Coordinates) {
 }
 
 // prepare подготавливает Solver для исполнения Solve.
-func (s *Solver) prepare(mz maze.Maze) {
+func (s *Solver) prepare(mz maze.Maze, tracer sutils.Tracer) {
+	clear(s.visited)
+
 	s.mz = mz
+	s.tracer = tracer
 	s.predecessors = sutils.NewPredecessors(s.mz.Height, s.mz.Width)
 }
@@ -1,7 +1,9 @@
 package dijkstra
 
 import (
+	"maps"
 	"math"
+	"time"
 
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
@@ -13,28 +15,71 @@ const INF = math.MaxInt
 
 // Solver - структура Solver по алгоритму Дейкстры.
 type Solver struct {
-	dist         map[cells.Coordinates]cells.Type // Хранит для каждой вершины информацию об её оценке пути.
-	heap         sutils.Heap                      // Куча минимумов, содержащая вершины и их оценку пути.
-	predecessors sutils.Predecessors              // Хранит для каждой вершины информацию о её предшественниках.
+	costs        sutils.CostModel                  // Модель стоимости путей.
+	dist         map[cells.Coordinates]sutils.Cost // Хранит для каждой вершины информацию об её оценке пути.
+	heap         sutils.Heap                       // Куча минимумов, содержащая вершины и их оценку пути.
+	predecessors sutils.Predecessors               // Хранит для каждой вершины информацию о её предшественниках.
+	explored     int                               // Количество вершин, извлечённых из кучи с актуальной оценкой.
+	tracer       sutils.Tracer                     // Получает события поиска; nil, если трассировка не нужна.
 }
 
-// NewSolver возвращает указатель на инициализированный Solver.
-func NewSolver() *Solver {
+// NewSolver возвращает указатель на инициализированный Solver со стоимостью путей в модели costs.
+func NewSolver(costs sutils.CostModel) *Solver {
 	ds := Solver{
-		dist: make(map[cells.Coordinates]cells.Type),
-		heap: sutils.New(),
+		costs: costs,
+		dist:  make(map[cells.Coordinates]sutils.Cost),
+		heap:  sutils.New(),
 	}
 
 	return &ds
 }
 
-// Solve находит и возвращает путь от start до end в mz в виде []cells.Coordinates.
-func (s *Solver) Solve(mz maze.Maze, start, end cells.Coordinates) []cells.Coordinates {
-	s.prepare(mz.Height, mz.Width)
+// Solve находит путь от start до end в mz и возвращает его вместе со статистикой поиска.
+func (s *Solver) Solve(mz maze.Maze, start, end cells.Coordinates) (sutils.Result, error) {
+	return s.SolveTraced(mz, start, end, nil)
+}
+
+// SolveTraced работает как Solve, дополнительно передавая tracer события поиска в порядке их возникновения.
+func (s *Solver) SolveTraced(mz maze.Maze, start, end cells.Coordinates, tracer sutils.Tracer) (sutils.Result, error) {
+	err := sutils.Validate(mz, start, end)
+	if err != nil {
+		return sutils.Result{}, err
+	}
+
+	begin := time.Now()
+
+	s.prepare(mz.Height, mz.Width, tracer)
 
 	s.dijkstra(mz, start, end)
 
-	return sutils.RestorePath(start, end, s.predecessors)
+	path := sutils.RestorePath(start, end, s.predecessors)
+	sutils.TracePath(s.tracer, path)
+
+	return sutils.NewResult(s.costs, mz, path, s.explored, time.Since(begin))
+}
+
+// Distances находит оценки путей от source до всех вершин mz (недостижимым соответствует INF)
+// и предшественников, по которым эти пути восстанавливаются.
+func (s *Solver) Distances(mz maze.Maze, source cells.Coordinates) (map[cells.Coordinates]sutils.Cost, sutils.Predecessors) {
+	s.prepare(mz.Height, mz.Width, nil)
+
+	s.dijkstra(mz, source, cells.Coordinates{X: sutils.MissingX, Y: sutils.MissingY}) // Конец недостижим - обходится всё.
+
+	return maps.Clone(s.dist), s.predecessors
+}
+
+// DistanceMap возвращает оценки путей от source до всех достижимых из неё клеток mz.
+func (s *Solver) DistanceMap(mz maze.Maze, source cells.Coordinates) (map[cells.Coordinates]sutils.Cost, error) {
+	err := sutils.Validate(mz, source, source)
+	if err != nil {
+		return nil, err
+	}
+
+	dist, _ := s.Distances(mz, source)
+
+	maps.DeleteFunc(dist, func(_ cells.Coordinates, d sutils.Cost) bool { return d == INF })
+
+	return dist, nil
 }
 
 // dijkstra находит кратчаший путь согласно алгоритму Дейкстры, записывая предшественника для каждой вершины.
@@ -45,44 +90,62 @@ func (s *Solver) dijkstra(mz maze.Maze, start, end cells.Coordinates) {
 	// Изначально оценка пути до каждой вершины равна INF.
 	//
 	// Алгоритм:
-	// 1) Оценка пути до начальной вершины становится равной её весу, начало с оценкой кладётся в кучу минимумов.
-	// 2) Достаётся вершина A с наименьшой оценкой пути из кучи.
+	// 1) Оценка пути до начальной вершины становится равной стоимости входа в неё, начало с оценкой кладётся в кучу минимумов.
+	// 2) Достаётся вершина A с наименьшой оценкой пути из кучи; если её оценка в куче больше записанной,
+	//    запись устарела (вершина уже была рассмотрена с лучшей оценкой) и пропускается.
 	// 3) Если полученная вершина является end, алгоритм прерывает своё выполнение.
-	// 4) Рассматривается каждая смежная с ней вершина, оценка до которой равна INF:
-	//   3.1) Обновляется оценка её пути.
-	//   3.2) Добавляется в кучу вместе с новой оценкой.
-	//   3.3) Записывается координата вершины A (необходимо для восстановления пути по предшественникам).
+	// 4) Рассматривается каждая смежная с ней вершина, оценку пути до которой удалось улучшить через A:
+	//   4.1) Обновляется оценка её пути.
+	//   4.2) Добавляется в кучу вместе с новой оценкой (прежняя запись в куче становится устаревшей).
+	//   4.3) Записывается координата вершины A (необходимо для восстановления пути по предшественникам).
 	//
 	// Пункты 2, 3, 4 повторяются, пока в куче существуют вершины, которые необходимо рассмотреть.
-	weight := mz.Cells[start].Type
+	weight := s.costs.Start(mz, start)
 
 	s.dist[start] = weight
 	s.heap.Push(sutils.Item{Vertex: start, Weight: weight})
+	sutils.Trace(s.tracer, sutils.Event{Kind: sutils.Pushed, Vertex: start})
 
 	for s.heap.Len() != 0 {
-		vertex1 := s.heap.Pop().Vertex // Получаем вершину с наименьшой оценкой пути из кучи.
+		item := s.heap.Pop() // Получаем вершину с наименьшой оценкой пути из кучи.
+		vertex1 := item.Vertex
+
+		if item.Weight > s.dist[vertex1] { // Запись устарела.
+			continue
+		}
+
+		s.explored++
+		sutils.Trace(s.tracer, sutils.Event{Kind: sutils.Popped, Vertex: vertex1})
 
 		if vertex1 == end {
 			break
 		}
 
 		for _, vertex2 := range mz.Cells[vertex1].Transitions { // Рассматриваем смежные вершины.
-			if s.dist[vertex2] == INF { // Если оценка пути равна INF.
-				s.dist[vertex2] = s.dist[vertex1] + mz.Cells[vertex2].Type         // Обновляем оценку пути.
+			newDist := s.dist[vertex1] + s.costs.Step(mz, vertex1, vertex2)
+
+			if newDist < s.dist[vertex2] { // Если оценку пути удалось улучшить.
+				s.dist[vertex2] = newDist                                          // Обновляем оценку пути.
 				s.heap.Push(sutils.Item{Vertex: vertex2, Weight: s.dist[vertex2]}) // Добавляем в кучу.
 				s.predecessors[vertex2] = vertex1                                  // Записываем предшественника для vertex2.
+
+				sutils.Trace(s.tracer, sutils.Event{Kind: sutils.Pushed, Vertex: vertex2})
+				sutils.Trace(s.tracer, sutils.Event{Kind: sutils.PredecessorSet, Vertex: vertex2, Predecessor: vertex1})
 			}
 		}
 	}
 }
 
 // prepare подготавливает Solver для исполнения Solve.
-func (s *Solver) prepare(height, width int) {
+func (s *Solver) prepare(height, width int, tracer sutils.Tracer) {
 	for y := 0; y < height; y++ {
 		for x := 0; x < width; x++ {
 			s.dist[cells.Coordinates{X: x, Y: y}] = INF // Изначально оценка пути до каждой вершины равна INF.
 		}
 	}
 
+	s.explored = 0
+	s.tracer = tracer
+	s.heap = sutils.New() // Куча могла сохранить вершины предыдущего вызова, прерванного на end.
 	s.predecessors = sutils.NewPredecessors(height, width)
 }
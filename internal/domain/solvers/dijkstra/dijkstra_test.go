@@ -6,7 +6,10 @@ import (
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/dijkstra"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils/sutilstest"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestDijkstraSolverSolve(t *testing.T) {
@@ -17,27 +20,48 @@ func TestDijkstraSolverSolve(t *testing.T) {
 	}
 
 	tests := []struct {
-		name     string
-		args     args
-		expected []cells.Coordinates
+		name         string
+		args         args
+		expected     []cells.Coordinates
+		expectedCost sutils.Cost
+		expectedErr  error
 	}{
 		{
 			name: "path doesn`t exist",
 			args: args{
-				mz:    maze.New(3, 3),
+				mz:    sutilstest.NewUnlinkedMaze(3, 3),
 				start: cells.Coordinates{X: 0, Y: 0},
 				end:   cells.Coordinates{X: 2, Y: 2},
 			},
-			expected: []cells.Coordinates{},
+			expectedErr: sutils.ErrUnreachable,
 		},
 		{
 			name: "path to self",
 			args: args{
-				mz:    maze.New(3, 3),
+				mz:    sutilstest.NewUnlinkedMaze(3, 3),
 				start: cells.Coordinates{X: 0, Y: 0},
 				end:   cells.Coordinates{X: 0, Y: 0},
 			},
-			expected: []cells.Coordinates{},
+			expected:     []cells.Coordinates{{X: 0, Y: 0}},
+			expectedCost: sutils.DefaultTypes()[cells.Pass],
+		},
+		{
+			name: "start on masked cell",
+			args: args{
+				mz:    maze.New(3, 3),
+				start: cells.Coordinates{X: 0, Y: 0},
+				end:   cells.Coordinates{X: 2, Y: 2},
+			},
+			expectedErr: sutils.ErrMaskedCell,
+		},
+		{
+			name: "end is out of bounds",
+			args: args{
+				mz:    sutilstest.NewUnlinkedMaze(3, 3),
+				start: cells.Coordinates{X: 0, Y: 0},
+				end:   cells.Coordinates{X: 3, Y: 0},
+			},
+			expectedErr: sutils.ErrOutOfBounds,
 		},
 		{
 			name: "there is only one path",
@@ -53,6 +77,7 @@ func TestDijkstraSolverSolve(t *testing.T) {
 				{X: 1, Y: 2},
 				{X: 2, Y: 2},
 			},
+			expectedCost: 10,
 		},
 		{
 			name: "path is the shortest of several",
@@ -68,20 +93,170 @@ func TestDijkstraSolverSolve(t *testing.T) {
 				{X: 2, Y: 1},
 				{X: 2, Y: 2},
 			},
+			expectedCost: 6,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := dijkstra.NewSolver()
+			s := dijkstra.NewSolver(sutils.DefaultCosts())
 
-			path := s.Solve(tt.args.mz, tt.args.start, tt.args.end)
+			result, err := s.Solve(tt.args.mz, tt.args.start, tt.args.end)
 
-			assert.Equal(t, tt.expected, path)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result.Path)
+			assert.Equal(t, tt.expectedCost, result.Cost)
+			assert.Positive(t, result.Explored)
 		})
 	}
 }
 
+func TestDijkstraSolverSolveOnBraidedMaze(t *testing.T) {
+	s := dijkstra.NewSolver(sutils.DefaultCosts())
+
+	for range 5 {
+		mz := sutilstest.NewBraidedMaze(t, 24, 24)
+
+		for i := range 20 {
+			start := cells.Coordinates{X: i, Y: 0}
+			end := cells.Coordinates{X: 23 - i, Y: 23}
+
+			result, err := s.Solve(mz, start, end)
+			require.NoError(t, err)
+
+			require.True(t, sutilstest.IsPathValid(mz, result.Path, start, end))
+			assert.Equal(t, optimalCost(mz, start, end), result.Cost)
+		}
+	}
+}
+
+func TestDijkstraSolverSolveTraced(t *testing.T) {
+	mz := newSeveralPathMaze()
+	start, end := cells.Coordinates{X: 0, Y: 0}, cells.Coordinates{X: 2, Y: 2}
+	recorder := sutils.Recorder{}
+
+	result, err := dijkstra.NewSolver(sutils.DefaultCosts()).SolveTraced(mz, start, end, &recorder)
+	require.NoError(t, err)
+
+	var (
+		popped    int
+		finalized []cells.Coordinates
+	)
+
+	pushed := make(map[cells.Coordinates]struct{})
+
+	for _, event := range recorder.Events {
+		switch event.Kind {
+		case sutils.Pushed:
+			pushed[event.Vertex] = struct{}{}
+		case sutils.Popped:
+			assert.Contains(t, pushed, event.Vertex) // Раскрыть можно лишь добавленную ранее вершину.
+			popped++
+		case sutils.PredecessorSet:
+			assert.Contains(t, mz.Cells[event.Predecessor].Transitions, event.Vertex)
+		case sutils.PathFinalized:
+			finalized = append(finalized, event.Vertex)
+		}
+	}
+
+	assert.Equal(t, sutils.Event{Kind: sutils.Pushed, Vertex: start}, recorder.Events[0])
+	assert.Equal(t, result.Explored, popped)
+	assert.Equal(t, result.Path, finalized)
+}
+
+func TestDijkstraSolverDistanceMap(t *testing.T) {
+	mz := sutilstest.NewBraidedMaze(t, 12, 12)
+	source := cells.Coordinates{X: 0, Y: 0}
+	s := dijkstra.NewSolver(sutils.DefaultCosts())
+
+	distances, err := s.DistanceMap(mz, source)
+	require.NoError(t, err)
+
+	assert.Len(t, distances, len(mz.Cells)) // Лабиринт связен - достижима каждая клетка.
+
+	for coords, d := range distances {
+		assert.Equal(t, optimalCost(mz, source, coords), d)
+	}
+
+	_, err = s.DistanceMap(mz, cells.Coordinates{X: -1, Y: 0})
+	assert.ErrorIs(t, err, sutils.ErrOutOfBounds)
+}
+
+func TestDijkstraSolverSolveWithCostModel(t *testing.T) {
+	top := []cells.Coordinates{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}}
+	bottom := []cells.Coordinates{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 1}, {X: 2, Y: 1}, {X: 2, Y: 0}}
+
+	expensivePass, err := sutils.NewCosts(map[cells.Type]sutils.Cost{cells.LightedPass: 1, cells.Pass: 5}, false, nil)
+	require.NoError(t, err)
+
+	tollEdge, err := sutils.NewCosts(map[cells.Type]sutils.Cost{cells.LightedPass: 1, cells.Pass: 2}, true,
+		map[sutils.Edge]sutils.Cost{{From: cells.Coordinates{X: 0, Y: 0}, To: cells.Coordinates{X: 1, Y: 0}}: 5})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		costs        sutils.CostModel
+		expected     []cells.Coordinates
+		expectedCost sutils.Cost
+	}{
+		{
+			name:         "default model",
+			costs:        sutils.DefaultCosts(),
+			expected:     top,
+			expectedCost: 6,
+		},
+		{
+			name:         "expensive pass without start cost",
+			costs:        expensivePass,
+			expected:     bottom,
+			expectedCost: 8,
+		},
+		{
+			name:         "toll on edge",
+			costs:        tollEdge,
+			expected:     bottom,
+			expectedCost: 7,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := dijkstra.NewSolver(tt.costs).Solve(newRingMaze(), top[0], top[2])
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expected, result.Path)
+			assert.Equal(t, tt.expectedCost, result.Cost)
+		})
+	}
+}
+
+// optimalCost возвращает стоимость кратчайшего пути в модели стоимости по умолчанию,
+// найденную алгоритмом Беллмана-Форда.
+func optimalCost(mz maze.Maze, start, end cells.Coordinates) sutils.Cost {
+	types := sutils.DefaultTypes()
+	dist := map[cells.Coordinates]sutils.Cost{start: types[mz.Cells[start].Type]}
+
+	for changed := true; changed; {
+		changed = false
+
+		for vertex1, d := range dist {
+			for _, vertex2 := range mz.Cells[vertex1].Transitions {
+				if old, ok := dist[vertex2]; !ok || d+types[mz.Cells[vertex2].Type] < old {
+					dist[vertex2] = d + types[mz.Cells[vertex2].Type]
+					changed = true
+				}
+			}
+		}
+	}
+
+	return dist[end]
+}
+
 func newOnePathMaze() maze.Maze {
 	OnePathMaze := maze.New(3, 3)
 
@@ -194,3 +369,23 @@ func newSeveralPathMaze() maze.Maze {
 
 	return mz
 }
+
+// newRingMaze возвращает кольцо 2x3: верхняя строка состоит из обычных проходов, нижняя - из освещённых.
+func newRingMaze() maze.Maze {
+	mz := maze.New(2, 3)
+	ring := []cells.Coordinates{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}, {X: 2, Y: 1}, {X: 1, Y: 1}, {X: 0, Y: 1}}
+
+	for i, coords := range ring {
+		next := ring[(i+1)%len(ring)]
+
+		mz.Cells[coords].Type = cells.Pass
+		if coords.Y == 1 {
+			mz.Cells[coords].Type = cells.LightedPass
+		}
+
+		mz.Cells[coords].Transitions = append(mz.Cells[coords].Transitions, next)
+		mz.Cells[next].Transitions = append(mz.Cells[next].Transitions, coords)
+	}
+
+	return mz
+}
@@ -0,0 +1,337 @@
+package dstarlite
+
+import (
+	"container/heap"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/gutils"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/astar"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils"
+)
+
+// INF обозначает ненайденную оценку; взята вдвое меньше максимума, чтобы сумма двух оценок не переполнялась.
+const INF sutils.Cost = math.MaxInt / 2
+
+// ErrNotInitialized возвращается, если Replan вызван до Solve.
+var ErrNotInitialized = errors.New("solver wasn`t initialized by Solve")
+
+// Solver - структура решателя по алгоритму D* Lite, сохраняющего состояние поиска между вызовами.
+type Solver struct {
+	costs    sutils.CostModel                  // Модель стоимости путей.
+	g        map[cells.Coordinates]sutils.Cost // Оценки пути от вершины до конца, полученные при раскрытии.
+	rhs      map[cells.Coordinates]sutils.Cost // Оценки пути от вершины до конца через лучшего соседа.
+	queue    *queue                            // Очередь несогласованных вершин (g != rhs).
+	km       sutils.Cost                       // Накопленная поправка ключей на перемещение начала.
+	explored int                               // Количество вершин, раскрытых в текущем вызове.
+	start    cells.Coordinates
+	end      cells.Coordinates
+	mz       maze.Maze
+}
+
+// NewSolver возвращает указатель на инициализированный Solver со стоимостью путей в модели costs.
+func NewSolver(costs sutils.CostModel) *Solver {
+	return &Solver{
+		costs: costs,
+		g:     make(map[cells.Coordinates]sutils.Cost),
+		rhs:   make(map[cells.Coordinates]sutils.Cost),
+	}
+}
+
+// Solve находит путь от start до end в mz с нуля, запоминая состояние поиска для последующих вызовов Replan.
+func (s *Solver) Solve(mz maze.Maze, start, end cells.Coordinates) (sutils.Result, error) {
+	err := sutils.Validate(mz, start, end)
+	if err != nil {
+		return sutils.Result{}, err
+	}
+
+	begin := time.Now()
+
+	s.prepare(mz, start, end)
+
+	s.rhs[end] = 0
+	s.queue.push(end, s.key(end))
+
+	s.computeShortestPath()
+
+	return sutils.NewResult(s.costs, mz, s.restorePath(), s.explored, time.Since(begin))
+}
+
+// Replan находит путь от start до прежнего конца в лабиринте, переданном в Solve, после того как у клеток changed
+// изменились типы или переходы. Лабиринт изменяется на месте вызывающей стороной; переходы должны связывать
+// только соседние по стороне клетки. Перерасчитываются лишь вершины, на оценки которых повлияли изменения.
+func (s *Solver) Replan(start cells.Coordinates, changed []cells.Coordinates) (sutils.Result, error) {
+	if s.queue == nil {
+		return sutils.Result{}, ErrNotInitialized
+	}
+
+	err := sutils.Validate(s.mz, start, s.end)
+	if err != nil {
+		return sutils.Result{}, err
+	}
+
+	begin := time.Now()
+
+	s.explored = 0
+	s.km += s.heuristic(s.start, start) // Ключи, вычисленные для прежнего начала, становятся заниженными на km.
+	s.start = start
+
+	for _, coords := range changed {
+		// Изменение клетки затрагивает стоимость входа в неё и её переходы, то есть оценки её самой и соседей.
+		s.updateVertex(coords)
+
+		for i := range gutils.Dx {
+			adjacent := cells.Coordinates{X: coords.X + gutils.Dx[i], Y: coords.Y + gutils.Dy[i]}
+
+			if gutils.IsInside(adjacent, s.mz.Height, s.mz.Width) {
+				s.updateVertex(adjacent)
+			}
+		}
+	}
+
+	s.computeShortestPath()
+
+	return sutils.NewResult(s.costs, s.mz, s.restorePath(), s.explored, time.Since(begin))
+}
+
+// computeShortestPath раскрывает несогласованные вершины, пока оценка начала не станет окончательной.
+func (s *Solver) computeShortestPath() {
+	// Суть алгоритма D* Lite (в текущей реализации):
+	//
+	// Поиск ведётся от конца к началу, поэтому оценки g и rhs вершин не зависят от положения начала.
+	// rhs(v) - стоимость пути от v до конца через лучшего соседа, посчитанная по g соседей; вершина,
+	// у которой g != rhs, несогласована и лежит в очереди с ключом [min(g, rhs) + h + km, min(g, rhs)].
+	//
+	// Алгоритм:
+	// 1) Достаётся вершина u с наименьшим ключом; если ключ устарел (вырос), она возвращается в очередь.
+	// 2) Если g(u) > rhs(u), оценка улучшилась: g(u) = rhs(u), и пересчитываются rhs соседей.
+	// 3) Иначе оценка ухудшилась: g(u) = INF, и пересчитываются rhs самой u и её соседей.
+	//
+	// Пункты повторяются, пока наименьший ключ меньше ключа начала или начало несогласовано.
+	// При изменении лабиринта пересчитываются только rhs затронутых вершин, и раскрываются лишь те,
+	// чьи оценки действительно поменялись.
+	for s.queue.Len() != 0 {
+		top := s.queue.peek()
+
+		if !top.key.less(s.key(s.start)) && valueOf(s.rhs, s.start) == valueOf(s.g, s.start) {
+			break
+		}
+
+		u := s.queue.pop().vertex
+		s.explored++
+
+		if oldKey, newKey := top.key, s.key(u); oldKey.less(newKey) {
+			s.queue.push(u, newKey)
+			continue
+		}
+
+		if valueOf(s.g, u) > valueOf(s.rhs, u) {
+			s.g[u] = valueOf(s.rhs, u)
+		} else {
+			s.g[u] = INF
+			s.updateVertex(u)
+		}
+
+		for _, predecessor := range s.mz.Cells[u].Transitions {
+			s.updateVertex(predecessor)
+		}
+	}
+}
+
+// updateVertex пересчитывает rhs вершины по её соседям и обновляет её положение в очереди.
+func (s *Solver) updateVertex(u cells.Coordinates) {
+	if u != s.end {
+		s.rhs[u] = s.bestNeighbour(u)
+	}
+
+	s.queue.remove(u)
+
+	if valueOf(s.g, u) != valueOf(s.rhs, u) {
+		s.queue.push(u, s.key(u))
+	}
+}
+
+// bestNeighbour возвращает наименьшую стоимость пути от u до конца через соседей u по их оценкам g.
+func (s *Solver) bestNeighbour(u cells.Coordinates) sutils.Cost {
+	best := INF
+
+	if s.mz.Cells[u].Type == cells.Wall {
+		return best
+	}
+
+	for _, v := range s.mz.Cells[u].Transitions {
+		if s.mz.Cells[v].Type == cells.Wall || valueOf(s.g, v) == INF {
+			continue
+		}
+
+		best = min(best, s.costs.Step(s.mz, u, v)+s.g[v])
+	}
+
+	return best
+}
+
+// key возвращает ключ вершины в очереди.
+func (s *Solver) key(u cells.Coordinates) key {
+	best := min(valueOf(s.g, u), valueOf(s.rhs, u))
+
+	if best == INF {
+		return key{first: INF, second: INF}
+	}
+
+	return key{first: best + s.heuristic(s.start, u) + s.km, second: best}
+}
+
+// heuristic возвращает манхэттенское расстояние между a и b, умноженное на нижнюю границу стоимости перехода.
+func (s *Solver) heuristic(a, b cells.Coordinates) sutils.Cost {
+	return s.costs.MinStep() * sutils.Cost(astar.Manhattan(a, b))
+}
+
+// restorePath восстанавливает путь от начала до конца, каждый раз переходя в соседа с наименьшей стоимостью.
+func (s *Solver) restorePath() []cells.Coordinates {
+	if valueOf(s.rhs, s.start) == INF {
+		return []cells.Coordinates{}
+	}
+
+	path := []cells.Coordinates{s.start}
+
+	for current := s.start; current != s.end; {
+		next, best := current, INF
+
+		for _, v := range s.mz.Cells[current].Transitions {
+			if s.mz.Cells[v].Type == cells.Wall || valueOf(s.g, v) == INF {
+				continue
+			}
+
+			if cost := s.costs.Step(s.mz, current, v) + s.g[v]; cost < best {
+				next, best = v, cost
+			}
+		}
+
+		if next == current || len(path) > len(s.mz.Cells) { // Оценки несогласованы - путь не восстанавливается.
+			return []cells.Coordinates{}
+		}
+
+		path = append(path, next)
+		current = next
+	}
+
+	return path
+}
+
+// valueOf возвращает оценку вершины из m; вершине, которой ещё нет в m, соответствует INF.
+func valueOf(m map[cells.Coordinates]sutils.Cost, u cells.Coordinates) sutils.Cost {
+	if value, ok := m[u]; ok {
+		return value
+	}
+
+	return INF
+}
+
+// prepare подготавливает Solver для исполнения Solve.
+func (s *Solver) prepare(mz maze.Maze, start, end cells.Coordinates) {
+	clear(s.g)
+	clear(s.rhs)
+
+	s.queue = newQueue()
+	s.km = 0
+	s.explored = 0
+	s.start, s.end = start, end
+	s.mz = mz
+}
+
+// key - ключ вершины в очереди, сравниваемый лексикографически.
+type key struct {
+	first  sutils.Cost
+	second sutils.Cost
+}
+
+// less возвращает true, если ключ k меньше other, иначе false.
+func (k key) less(other key) bool {
+	return k.first < other.first || k.first == other.first && k.second < other.second
+}
+
+// entry - запись очереди.
+type entry struct {
+	vertex cells.Coordinates
+	key    key
+}
+
+// queue - очередь с приоритетом и ленивым удалением: актуальный ключ каждой вершины хранится в keys,
+// а записи кучи с другим ключом считаются устаревшими и пропускаются.
+type queue struct {
+	heap entries
+	keys map[cells.Coordinates]key
+}
+
+// newQueue возвращает указатель на инициализированный queue.
+func newQueue() *queue {
+	return &queue{keys: make(map[cells.Coordinates]key)}
+}
+
+// Len возвращает количество вершин в очереди.
+func (q *queue) Len() int {
+	return len(q.keys)
+}
+
+// push добавляет вершину в очередь или обновляет её ключ.
+func (q *queue) push(vertex cells.Coordinates, k key) {
+	q.keys[vertex] = k
+	heap.Push(&q.heap, entry{vertex: vertex, key: k})
+}
+
+// remove удаляет вершину из очереди.
+func (q *queue) remove(vertex cells.Coordinates) {
+	delete(q.keys, vertex)
+}
+
+// peek возвращает актуальную запись с наименьшим ключом, не удаляя её; очередь не должна быть пуста.
+func (q *queue) peek() entry {
+	q.dropStale()
+	return q.heap[0]
+}
+
+// pop возвращает актуальную запись с наименьшим ключом, удаляя её; очередь не должна быть пуста.
+func (q *queue) pop() entry {
+	q.dropStale()
+
+	e := heap.Pop(&q.heap).(entry)
+	delete(q.keys, e.vertex)
+
+	return e
+}
+
+// dropStale удаляет устаревшие записи с вершины кучи.
+func (q *queue) dropStale() {
+	for {
+		top := q.heap[0]
+
+		if k, ok := q.keys[top.vertex]; ok && k == top.key {
+			return
+		}
+
+		heap.Pop(&q.heap)
+	}
+}
+
+// entries реализует интерфейс heap.Interface, описанный в container/heap.
+type entries []entry
+
+func (e entries) Len() int { return len(e) }
+
+func (e entries) Less(i, j int) bool { return e[i].key.less(e[j].key) }
+
+func (e entries) Swap(i, j int) { e[i], e[j] = e[j], e[i] }
+
+func (e *entries) Push(x any) { *e = append(*e, x.(entry)) }
+
+func (e *entries) Pop() any {
+	old := *e
+	n := len(old)
+	item := old[n-1]
+	*e = old[:n-1]
+
+	return item
+}
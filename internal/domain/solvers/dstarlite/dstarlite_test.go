@@ -0,0 +1,165 @@
+package dstarlite_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/cave"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/gutils"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/placement"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/bfs"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/dijkstra"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/dstarlite"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils/sutilstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDStarLiteSolverReplanBeforeSolve(t *testing.T) {
+	_, err := dstarlite.NewSolver(sutils.DefaultCosts()).Replan(cells.Coordinates{X: 0, Y: 0}, nil)
+
+	assert.ErrorIs(t, err, dstarlite.ErrNotInitialized)
+}
+
+func TestDStarLiteSolverReplanOnRing(t *testing.T) {
+	// Кольцо 2x3: короткий путь по верхней строке и обход по нижней.
+	mz := maze.New(2, 3)
+	ring := []cells.Coordinates{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}, {X: 2, Y: 1}, {X: 1, Y: 1}, {X: 0, Y: 1}}
+
+	for i, coords := range ring {
+		next := ring[(i+1)%len(ring)]
+
+		mz.Cells[coords].Type = cells.Pass
+		mz.Cells[coords].Transitions = append(mz.Cells[coords].Transitions, next)
+		mz.Cells[next].Transitions = append(mz.Cells[next].Transitions, coords)
+	}
+
+	s := dstarlite.NewSolver(sutils.DefaultCosts())
+	start, end := cells.Coordinates{X: 0, Y: 0}, cells.Coordinates{X: 2, Y: 0}
+
+	result, err := s.Solve(mz, start, end)
+	require.NoError(t, err)
+	assert.Equal(t, []cells.Coordinates{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}}, result.Path)
+
+	blocked := cells.Coordinates{X: 1, Y: 0}
+	mz.Cells[blocked].Type = cells.Wall
+
+	result, err = s.Replan(start, []cells.Coordinates{blocked})
+	require.NoError(t, err)
+	assert.Equal(t, []cells.Coordinates{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 1}, {X: 2, Y: 1}, {X: 2, Y: 0}}, result.Path)
+}
+
+func TestDStarLiteSolverReplanOnCave(t *testing.T) {
+	mz, err := cave.NewGenerator().Generate(24, 24)
+	require.NoError(t, err)
+
+	start, end, _, err := placement.Diameter(mz) // Самые удалённые клетки дают длинный поиск, который есть что чинить.
+	require.NoError(t, err)
+
+	s := dstarlite.NewSolver(sutils.DefaultCosts())
+	reference := dijkstra.NewSolver(sutils.DefaultCosts())
+
+	result, err := s.Solve(mz, start, end)
+	expected, expectedErr := reference.Solve(mz, start, end)
+
+	assert.Equal(t, expectedErr, err)
+	assert.Equal(t, expected.Cost, result.Cost)
+
+	var replanned, solved int
+
+	for range 50 {
+		// Начало продвигается на шаг по последнему найденному пути, как это делал бы агент.
+		if len(result.Path) > 2 {
+			start = result.Path[1]
+		}
+
+		changed := editMaze(t, mz, start, end)
+
+		result, err = s.Replan(start, changed)
+		expected, expectedErr = reference.Solve(mz, start, end)
+
+		require.Equal(t, expectedErr, err)
+		require.Equal(t, expected.Cost, result.Cost)
+		assert.True(t, sutilstest.IsPathValid(mz, result.Path, start, end))
+
+		fresh, err := dstarlite.NewSolver(sutils.DefaultCosts()).Solve(mz, start, end)
+		require.NoError(t, err)
+
+		replanned += result.Explored
+		solved += fresh.Explored
+	}
+
+	// Правка одной клетки чинит лишь затронутую часть поиска, а не повторяет его с нуля.
+	require.Positive(t, solved)
+	assert.Less(t, replanned, solved/2)
+}
+
+// editMaze случайно изменяет клетку mz, отличную от start и end, так, чтобы end оставался достижим из start,
+// и возвращает изменённые клетки.
+func editMaze(t *testing.T, mz maze.Maze, start, end cells.Coordinates) []cells.Coordinates {
+	t.Helper()
+
+	for {
+		coords, err := gutils.GetRandomCoords(mz.Height, mz.Width)
+		require.NoError(t, err)
+
+		cell := mz.Cells[coords]
+
+		if coords == start || coords == end || cell.Type == cells.Wall {
+			continue
+		}
+
+		action, err := gutils.GetRandomInt(3)
+		require.NoError(t, err)
+
+		switch action {
+		case 0: // Проход становится стеной и теряет все переходы.
+			changed := append([]cells.Coordinates{coords}, cell.Transitions...)
+			previousType, previousTransitions := cell.Type, cell.Transitions
+
+			for _, other := range cell.Transitions {
+				unlink(mz, other, coords)
+			}
+
+			cell.Type, cell.Transitions = cells.Wall, nil
+
+			if _, err := bfs.NewSolver(sutils.DefaultCosts()).Solve(mz, start, end); err != nil { // Стена отрезала конец.
+				cell.Type, cell.Transitions = previousType, previousTransitions
+
+				for _, other := range previousTransitions {
+					mz.Cells[other].Transitions = append(mz.Cells[other].Transitions, coords)
+				}
+
+				continue
+			}
+
+			return changed
+		case 1: // Меняется вес прохода.
+			cell.Type = cells.LightedPass + cells.Pass - cell.Type
+
+			return []cells.Coordinates{coords}
+		default: // Прорезается переход в соседний проход.
+			other, err := gutils.GetRandomAdjacentCoords(coords, mz.Height, mz.Width)
+			require.NoError(t, err)
+
+			if mz.Cells[other].Type == cells.Wall || slices.Contains(cell.Transitions, other) {
+				continue
+			}
+
+			cell.Transitions = append(cell.Transitions, other)
+			mz.Cells[other].Transitions = append(mz.Cells[other].Transitions, coords)
+
+			return []cells.Coordinates{coords, other}
+		}
+	}
+}
+
+// unlink удаляет переход из from в to.
+func unlink(mz maze.Maze, from, to cells.Coordinates) {
+	mz.Cells[from].Transitions = slices.DeleteFunc(mz.Cells[from].Transitions, func(c cells.Coordinates) bool {
+		return c == to
+	})
+}
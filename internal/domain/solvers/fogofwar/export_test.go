@@ -0,0 +1,10 @@
+package fogofwar
+
+import "github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
+
+// Belief подготавливает Solver к исследованию mz и возвращает начальное представление агента о лабиринте.
+func (s *Solver) Belief(mz maze.Maze) maze.Maze {
+	s.prepare(mz)
+
+	return s.belief
+}
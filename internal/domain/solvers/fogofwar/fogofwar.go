@@ -0,0 +1,227 @@
+package fogofwar
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/gutils"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/dijkstra"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/dstarlite"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils"
+)
+
+// Exploration содержит результат исследования лабиринта агентом.
+type Exploration struct {
+	Trajectory []cells.Coordinates // Все пройденные агентом клетки по порядку, включая возвраты.
+	Path       []cells.Coordinates // Путь от начала до конца с удалёнными петлями.
+	Discovered int                 // Количество клеток, которые агент увидел.
+	Cost       sutils.Cost         // Стоимость траектории.
+	Optimal    sutils.Result       // Кратчайший путь, найденный при полном знании лабиринта.
+}
+
+// Steps возвращает количество шагов, сделанных агентом.
+func (e Exploration) Steps() int {
+	return len(e.Trajectory) - 1
+}
+
+// Overhead возвращает, во сколько раз траектория агента дороже кратчайшего пути.
+func (e Exploration) Overhead() float64 {
+	return float64(e.Cost) / float64(e.Optimal.Cost)
+}
+
+// Solver - структура решателя, моделирующего агента, который исследует лабиринт в тумане войны.
+type Solver struct {
+	costs   sutils.CostModel               // Модель стоимости путей.
+	radius  int                            // Дальность прямой видимости в клетках.
+	planner *dstarlite.Solver              // Планировщик, перестраивающий путь по мере открытия лабиринта.
+	known   map[cells.Coordinates]struct{} // Множество клеток, которые агент уже видел.
+	belief  maze.Maze                      // Представление агента о лабиринте.
+	unknown cells.Type                     // Тип, которым агент считает неизвестные клетки.
+	mz      maze.Maze
+}
+
+// NewSolver возвращает указатель на инициализированный Solver, агент которого видит на radius клеток,
+// со стоимостью путей в модели costs.
+func NewSolver(costs sutils.CostModel, radius int) *Solver {
+	return &Solver{
+		costs:   costs,
+		radius:  radius,
+		planner: dstarlite.NewSolver(costs),
+		known:   make(map[cells.Coordinates]struct{}),
+		unknown: cheapestType(costs),
+	}
+}
+
+// Solve проводит агента от start до end в mz и возвращает путь без петель вместе со статистикой;
+// исследованными считаются клетки, которые агент увидел.
+func (s *Solver) Solve(mz maze.Maze, start, end cells.Coordinates) (sutils.Result, error) {
+	begin := time.Now()
+
+	exploration, err := s.Explore(mz, start, end)
+	if err != nil {
+		return sutils.Result{}, err
+	}
+
+	return sutils.NewResult(s.costs, mz, exploration.Path, exploration.Discovered, time.Since(begin))
+}
+
+// Explore проводит агента от start до end в mz и возвращает его траекторию вместе со сравнением с кратчайшим путём.
+func (s *Solver) Explore(mz maze.Maze, start, end cells.Coordinates) (Exploration, error) {
+	// Суть исследования в тумане войны:
+	//
+	// Агент знает лишь координаты выхода. Непосещённые клетки он оптимистично считает проходами самого дешёвого
+	// типа, связанными со всеми соседями. Из каждой клетки он видит клетки по прямой вдоль коридоров на radius клеток
+	// и узнаёт их настоящие типы и переходы.
+	//
+	// Алгоритм:
+	// 1) Агент осматривается и планирует по своему представлению кратчайший путь до выхода.
+	// 2) Агент делает один шаг по плану и осматривается.
+	// 3) Если увиденное противоречит представлению, план чинится D* Lite без поиска с нуля.
+	//
+	// Пункты 2, 3 повторяются, пока агент не дойдёт до выхода. Представление агента содержит все
+	// настоящие переходы, поэтому, если выход достижим, агент до него доходит.
+	err := sutils.Validate(mz, start, end)
+	if err != nil {
+		return Exploration{}, err
+	}
+
+	optimal, err := dijkstra.NewSolver(s.costs).Solve(mz, start, end)
+	if err != nil {
+		return Exploration{}, err
+	}
+
+	s.prepare(mz)
+
+	s.observe(start)
+
+	plan, err := s.planner.Solve(s.belief, start, end)
+	if err != nil {
+		return Exploration{}, fmt.Errorf("can`t plan on partial map: %w", err)
+	}
+
+	trajectory := []cells.Coordinates{start}
+
+	for current := start; current != end; {
+		current = plan.Path[1]
+		trajectory = append(trajectory, current)
+
+		plan, err = s.planner.Replan(current, s.observe(current))
+		if err != nil {
+			return Exploration{}, fmt.Errorf("can`t replan on partial map: %w", err)
+		}
+	}
+
+	return Exploration{
+		Trajectory: trajectory,
+		Path:       sutils.EraseLoops(trajectory),
+		Discovered: len(s.known),
+		Cost:       sutils.PathCost(s.costs, mz, trajectory),
+		Optimal:    optimal,
+	}, nil
+}
+
+// observe открывает клетки, видимые из coords по прямой, и возвращает клетки, представление о которых изменилось.
+func (s *Solver) observe(coords cells.Coordinates) []cells.Coordinates {
+	changed := s.reveal(coords)
+
+	for i := range gutils.Dx {
+		current := coords
+
+		for range s.radius {
+			next := cells.Coordinates{X: current.X + gutils.Dx[i], Y: current.Y + gutils.Dy[i]}
+
+			if !slices.Contains(s.mz.Cells[current].Transitions, next) { // Взгляд упёрся в стену.
+				break
+			}
+
+			changed = append(changed, s.reveal(next)...)
+			current = next
+		}
+	}
+
+	return changed
+}
+
+// reveal переносит настоящие тип и переходы клетки в представление агента
+// и возвращает клетки, представление о которых могло измениться.
+func (s *Solver) reveal(coords cells.Coordinates) []cells.Coordinates {
+	if _, ok := s.known[coords]; ok {
+		return nil
+	}
+
+	s.known[coords] = struct{}{}
+
+	cell := s.mz.Cells[coords]
+
+	s.belief.Cells[coords].Type = cell.Type
+	s.belief.Cells[coords].Transitions = slices.Clone(cell.Transitions)
+
+	changed := []cells.Coordinates{coords}
+
+	for i := range gutils.Dx {
+		adjacent := cells.Coordinates{X: coords.X + gutils.Dx[i], Y: coords.Y + gutils.Dy[i]}
+
+		if _, ok := s.known[adjacent]; ok || !gutils.IsInside(adjacent, s.mz.Height, s.mz.Width) {
+			continue
+		}
+
+		// Неизвестный сосед оставляет переход в открытую клетку, только если он действительно существует.
+		if !slices.Contains(cell.Transitions, adjacent) {
+			s.belief.Cells[adjacent].Transitions = slices.DeleteFunc(s.belief.Cells[adjacent].Transitions,
+				func(c cells.Coordinates) bool { return c == coords })
+		}
+
+		changed = append(changed, adjacent)
+	}
+
+	return changed
+}
+
+// prepare подготавливает Solver для исполнения Explore: представление агента - сетка проходов самого дешёвого типа.
+func (s *Solver) prepare(mz maze.Maze) {
+	clear(s.known)
+
+	s.mz = mz
+	s.belief = maze.New(mz.Height, mz.Width)
+
+	for coords, cell := range s.belief.Cells {
+		cell.Type = s.unknown
+
+		for i := range gutils.Dx {
+			adjacent := cells.Coordinates{X: coords.X + gutils.Dx[i], Y: coords.Y + gutils.Dy[i]}
+
+			if gutils.IsInside(adjacent, mz.Height, mz.Width) {
+				cell.Transitions = append(cell.Transitions, adjacent)
+			}
+		}
+	}
+}
+
+// cheapestType возвращает проходимый тип клетки, вход в который в модели costs стоит меньше всего.
+// Стоимость типа измеряется на пробном лабиринте из двух клеток: надбавка за переход между ними
+// не зависит от типа клетки и не влияет на сравнение.
+func cheapestType(costs sutils.CostModel) cells.Type {
+	probe := maze.New(1, 2)
+	from, to := cells.Coordinates{X: 0, Y: 0}, cells.Coordinates{X: 1, Y: 0}
+
+	cheapest := cells.LightedPass
+	probe.Cells[to].Type = cheapest
+	minCost := costs.Step(probe, from, to)
+
+	for _, t := range cells.Types {
+		if t == cells.Wall {
+			continue
+		}
+
+		probe.Cells[to].Type = t
+
+		if cost := costs.Step(probe, from, to); cost < minCost {
+			cheapest, minCost = t, cost
+		}
+	}
+
+	return cheapest
+}
@@ -0,0 +1,163 @@
+package fogofwar_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/cave"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/prim"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/fogofwar"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFogOfWarSolverExplore(t *testing.T) {
+	perfect, err := prim.NewGenerator().Generate(16, 16)
+	require.NoError(t, err)
+
+	braided, err := cave.NewGenerator().Generate(24, 24)
+	require.NoError(t, err)
+
+	var passages []cells.Coordinates
+
+	for coords, cell := range braided.Cells {
+		if cell.Type != cells.Wall {
+			passages = append(passages, coords)
+		}
+	}
+
+	type args struct {
+		mz     maze.Maze
+		radius int
+		start  cells.Coordinates
+		end    cells.Coordinates
+	}
+
+	tests := []struct {
+		name string
+		args args
+	}{
+		{
+			name: "perfect maze, agent sees only its cell",
+			args: args{mz: perfect, radius: 0, start: cells.Coordinates{X: 0, Y: 0}, end: cells.Coordinates{X: 15, Y: 15}},
+		},
+		{
+			name: "perfect maze, agent sees along corridors",
+			args: args{mz: perfect, radius: 4, start: cells.Coordinates{X: 0, Y: 0}, end: cells.Coordinates{X: 15, Y: 15}},
+		},
+		{
+			name: "cave",
+			args: args{mz: braided, radius: 3, start: passages[0], end: passages[len(passages)-1]},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exploration, err := fogofwar.NewSolver(sutils.DefaultCosts(), tt.args.radius).Explore(tt.args.mz, tt.args.start, tt.args.end)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.args.start, exploration.Trajectory[0])
+			assert.Equal(t, tt.args.end, exploration.Trajectory[exploration.Steps()])
+			assert.True(t, isTrajectoryValid(tt.args.mz, exploration.Trajectory))
+			assert.GreaterOrEqual(t, exploration.Cost, exploration.Optimal.Cost)
+			assert.GreaterOrEqual(t, exploration.Overhead(), 1.0)
+			assert.GreaterOrEqual(t, exploration.Discovered, len(exploration.Path))
+		})
+	}
+}
+
+func TestFogOfWarSolverExploreCorridor(t *testing.T) {
+	// В прямом коридоре агент сразу идёт к выходу, а кратчайший путь совпадает с траекторией.
+	mz := maze.New(1, 6)
+
+	for x := range 6 {
+		coords := cells.Coordinates{X: x, Y: 0}
+		mz.Cells[coords].Type = cells.Pass
+
+		if x > 0 {
+			previous := cells.Coordinates{X: x - 1, Y: 0}
+			mz.Cells[previous].Transitions = append(mz.Cells[previous].Transitions, coords)
+			mz.Cells[coords].Transitions = append(mz.Cells[coords].Transitions, previous)
+		}
+	}
+
+	s := fogofwar.NewSolver(sutils.DefaultCosts(), 2)
+
+	exploration, err := s.Explore(mz, cells.Coordinates{X: 0, Y: 0}, cells.Coordinates{X: 5, Y: 0})
+	require.NoError(t, err)
+
+	assert.Equal(t, 5, exploration.Steps())
+	assert.Equal(t, exploration.Optimal.Path, exploration.Trajectory)
+	assert.InDelta(t, 1.0, exploration.Overhead(), 1e-9)
+}
+
+func TestFogOfWarSolverSolveUnreachable(t *testing.T) {
+	mz := maze.New(2, 2)
+	for _, cell := range mz.Cells {
+		cell.Type = cells.Pass
+	}
+
+	_, err := fogofwar.NewSolver(sutils.DefaultCosts(), 3).Solve(mz, cells.Coordinates{X: 0, Y: 0}, cells.Coordinates{X: 1, Y: 1})
+
+	assert.ErrorIs(t, err, sutils.ErrUnreachable)
+}
+
+func TestFogOfWarSolverExploreInvalidEndpoints(t *testing.T) {
+	mz := maze.New(2, 2)
+	mz.Cells[cells.Coordinates{X: 0, Y: 0}].Type = cells.Pass
+
+	tests := []struct {
+		name        string
+		end         cells.Coordinates
+		expectedErr error
+	}{
+		{name: "end is outside the maze", end: cells.Coordinates{X: 2, Y: 0}, expectedErr: sutils.ErrOutOfBounds},
+		{name: "end is a wall", end: cells.Coordinates{X: 1, Y: 1}, expectedErr: sutils.ErrMaskedCell},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := fogofwar.NewSolver(sutils.DefaultCosts(), 1).Explore(mz, cells.Coordinates{X: 0, Y: 0}, tt.end)
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestFogOfWarSolverBelief(t *testing.T) {
+	cheapPass, err := sutils.NewCosts(map[cells.Type]sutils.Cost{cells.LightedPass: 3, cells.Pass: 1}, true, nil)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		costs    sutils.CostModel
+		expected cells.Type
+	}{
+		{name: "lighted passes are cheaper", costs: sutils.DefaultCosts(), expected: cells.LightedPass},
+		{name: "passes are cheaper", costs: cheapPass, expected: cells.Pass},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			belief := fogofwar.NewSolver(tt.costs, 1).Belief(maze.New(3, 4))
+
+			for coords, cell := range belief.Cells {
+				assert.Equal(t, tt.expected, cell.Type, "cell %d:%d", coords.X, coords.Y)
+			}
+		})
+	}
+}
+
+// isTrajectoryValid проверяет, что между каждой парой последовательных клеток траектории есть переход.
+func isTrajectoryValid(mz maze.Maze, trajectory []cells.Coordinates) bool {
+	for i := 1; i < len(trajectory); i++ {
+		if !slices.Contains(mz.Cells[trajectory[i-1]].Transitions, trajectory[i]) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,492 @@
+package jps
+
+import (
+	"container/heap"
+	"slices"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/gutils"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/astar"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils"
+)
+
+// Направления - индексы сдвигов в gutils.Dx и gutils.Dy. Противоположные направления в сумме дают 3.
+const (
+	west  = 0
+	north = 1
+	south = 2
+	east  = 3
+)
+
+// Solver - структура решателя по поиску с прыжками (jump point search) для лабиринтов с открытыми областями.
+type Solver struct {
+	costs    sutils.CostModel                        // Модель стоимости путей.
+	dist     map[cells.Coordinates]sutils.Cost       // Хранит для каждой точки прыжка лучшую известную оценку пути.
+	parents  map[cells.Coordinates]cells.Coordinates // Хранит для каждой точки прыжка точку, из которой в неё прыгнули.
+	closed   map[cells.Coordinates]struct{}          // Хранит множество точек прыжка, оценка пути до которых окончательна.
+	grid     []vertex                                // Клетки лабиринта с переходами по направлениям; индекс - y * Width + x.
+	floor    sutils.Cost                             // Наименьшая стоимость перехода в лабиринте, масштабирующая эвристику.
+	trail    []mark                                  // Клетки, пройденные текущим горизонтальным прыжком.
+	explored int                                     // Количество раскрытых точек прыжка.
+	end      cells.Coordinates                       // Конец текущего поиска.
+	mz       maze.Maze
+}
+
+// NewSolver возвращает указатель на инициализированный Solver со стоимостью путей в модели costs.
+func NewSolver(costs sutils.CostModel) *Solver {
+	return &Solver{
+		costs:   costs,
+		dist:    make(map[cells.Coordinates]sutils.Cost),
+		parents: make(map[cells.Coordinates]cells.Coordinates),
+		closed:  make(map[cells.Coordinates]struct{}),
+	}
+}
+
+// Solve находит путь от start до end в mz и возвращает его вместе со статистикой поиска;
+// исследованными считаются раскрытые точки прыжка.
+func (s *Solver) Solve(mz maze.Maze, start, end cells.Coordinates) (sutils.Result, error) {
+	err := sutils.Validate(mz, start, end)
+	if err != nil {
+		return sutils.Result{}, err
+	}
+
+	begin := time.Now()
+
+	s.prepare(mz, end)
+
+	return sutils.NewResult(s.costs, mz, s.search(start), s.explored, time.Since(begin))
+}
+
+// search находит кратчайший путь из start алгоритмом A* по точкам прыжка.
+func (s *Solver) search(start cells.Coordinates) []cells.Coordinates {
+	// Суть поиска с прыжками (в текущей реализации для переходов по сторонам клеток):
+	//
+	// В открытой области много равноценных кратчайших путей, и A* раскрывает каждую клетку каждого из них.
+	// Поиск с прыжками рассматривает только канонические пути: сначала по вертикали, затем по горизонтали,
+	// а с горизонтали на вертикаль - лишь там, где иначе в соседнюю строку не попасть так же дёшево.
+	//
+	// Алгоритм:
+	// 1) Из раскрываемой точки прыжка поиск идёт по прямой во все четыре стороны без записи промежуточных клеток.
+	// 2) Горизонтальный прыжок останавливается в клетке, из которой вертикальный переход в соседнюю
+	//    строку дешевле пути по параллельной строке (вынужденный сосед); такая клетка - точка прыжка.
+	// 3) Вертикальный прыжок в каждой клетке выполняет горизонтальные прыжки в обе стороны; если хотя бы
+	//    один из них нашёл точку прыжка, точкой прыжка становится и сама клетка.
+	// 4) Прыжок останавливается в конце, в тупике и там, где меняется стоимость шага: прыгать можно
+	//    только через клетки одной стоимости, поэтому смешанная местность обрабатывается корректно.
+	// 5) Найденные точки прыжка раскрываются в порядке A* с манхэттенской эвристикой.
+	//
+	// Из каждой точки прыгают во все стороны, поэтому точка прыжка - просто клетка: на смешанной местности,
+	// где прыжки короткие, вершин не больше, чем у алгоритма Дейкстры.
+	open := &nodes{}
+	s.dist[start] = s.costs.Start(s.mz, start)
+	heap.Push(open, item{coords: start, dist: s.dist[start], priority: s.dist[start] + s.estimate(start)})
+
+	for open.Len() != 0 {
+		current := heap.Pop(open).(item).coords
+
+		if _, ok := s.closed[current]; ok { // Устаревшая запись.
+			continue
+		}
+
+		s.closed[current] = struct{}{}
+		s.explored++
+
+		if current == s.end {
+			return s.restorePath(start, current)
+		}
+
+		for direction := range gutils.Dx {
+			successor, cost, ok := s.jump(current, direction)
+			if !ok {
+				continue
+			}
+
+			if _, ok := s.closed[successor]; ok {
+				continue
+			}
+
+			newDist := s.dist[current] + cost
+
+			if oldDist, ok := s.dist[successor]; !ok || newDist < oldDist {
+				s.dist[successor] = newDist
+				s.parents[successor] = current
+				heap.Push(open, item{coords: successor, dist: newDist, priority: newDist + s.estimate(successor)})
+			}
+		}
+	}
+
+	return []cells.Coordinates{}
+}
+
+// jump прыгает из точки from в направлении direction и возвращает найденную точку прыжка
+// и стоимость пути до неё; если точки прыжка нет, возвращается false.
+func (s *Solver) jump(from cells.Coordinates, direction int) (cells.Coordinates, sutils.Cost, bool) {
+	if isVertical(direction) {
+		return s.jumpVertical(from, direction)
+	}
+
+	// Соседние строки покрыты вертикальными прыжками из самой from.
+	sides := perpendicular(direction)
+	bounds := [2]bound{s.vertexAt(from).bound(sides[0]), s.vertexAt(from).bound(sides[1])}
+
+	return s.jumpHorizontal(from, direction, bounds)
+}
+
+// jumpHorizontal прыгает из from по горизонтали в направлении direction. bounds для каждой из двух вертикалей
+// хранит, насколько путь до соседней клетки в этой строке может быть дороже пути до текущей клетки прыжка.
+func (s *Solver) jumpHorizontal(from cells.Coordinates, direction int, bounds [2]bound) (cells.Coordinates, sutils.Cost, bool) {
+	var (
+		total    sutils.Cost
+		scanCost sutils.Cost = -1 // Стоимость шага, через которую идёт прыжок.
+	)
+
+	s.trail = s.trail[:0]
+	sides := perpendicular(direction)
+
+	for current, cell := from, s.vertexAt(from); ; {
+		cost, ok := cell.step(direction)
+		if !ok { // Тупик.
+			s.markClear()
+			return cells.Coordinates{}, 0, false
+		}
+
+		next := adjacent(current, direction)
+		total += cost
+
+		if scanCost != -1 && cost != scanCost || next == s.end { // Меняется стоимость шага или найден конец.
+			return next, total, true
+		}
+
+		scanCost = cost
+		beside := [2]*vertex{s.vertexAt(adjacent(current, sides[0])), s.vertexAt(adjacent(current, sides[1]))}
+
+		if s.probe(current, direction, bounds, cell, beside) {
+			s.markClear()
+			return cells.Coordinates{}, 0, false
+		}
+
+		nextCell := s.vertexAt(next)
+		forced := false
+
+		for i, vertical := range sides {
+			// Соседняя строка покрыта параллельным прыжком, пока в ней есть такой же горизонтальный переход.
+			besideCost, besideOk := beside[i].step(direction)
+			if bounds[i].ok && besideOk {
+				bounds[i].value += besideCost - cost
+			} else {
+				bounds[i].ok = false
+			}
+
+			upCost, upOk := nextCell.step(vertical)
+			if !upOk {
+				continue
+			}
+
+			// Вынужденный сосед: по параллельной строке до него не добраться так же дёшево, как через next.
+			if !bounds[i].ok || bounds[i].value > upCost {
+				forced = true
+			}
+		}
+
+		if forced {
+			return next, total, true
+		}
+
+		current, cell = next, nextCell
+	}
+}
+
+// probe возвращает true, если горизонтальный прыжок из coords в направлении direction с границами bounds
+// заведомо ничего не найдёт, иначе запоминает coords, чтобы отметить её при неудаче прыжка, и возвращает false.
+// cell - клетка coords, beside - её соседи по вертикалям в порядке perpendicular(direction).
+func (s *Solver) probe(coords cells.Coordinates, direction int, bounds [2]bound, cell *vertex, beside [2]*vertex) bool {
+	// Вертикальный прыжок выполняет горизонтальные прыжки из каждой клетки с одними и теми же границами,
+	// и разные вертикальные прыжки многократно проходят одни и те же строки. Поэтому для каждой клетки
+	// запоминается, что горизонтальный прыжок из неё с такими границами ничего не находит: тогда ничего
+	// не найдёт и любой прыжок, проходящий через неё с границами не слабее этих. Верно и обратное:
+	// если ничего не нашёл прыжок, прошедший через клетку с границами не сильнее этих, она отмечается.
+	for i, vertical := range perpendicular(direction) {
+		// Вертикальный прыжок в направлении vertical приходит в coords из соседа по другую сторону.
+		behind := 1 - i
+
+		cost, ok := beside[behind].step(vertical)
+		if !ok {
+			continue
+		}
+
+		var standard [2]bound
+
+		standard[i] = cell.bound(vertical)
+		standard[behind] = bound{value: -cost, ok: true}
+
+		bit := clearBit(direction, vertical)
+
+		if cell.clear&bit != 0 && isStronger(bounds[0], standard[0]) && isStronger(bounds[1], standard[1]) {
+			return true
+		}
+
+		if isStronger(standard[0], bounds[0]) && isStronger(standard[1], bounds[1]) {
+			s.trail = append(s.trail, mark{coords: coords, bit: bit})
+		}
+	}
+
+	return false
+}
+
+// markClear отмечает клетки, запомненные при неудачном прыжке.
+func (s *Solver) markClear() {
+	for _, m := range s.trail {
+		s.grid[m.coords.Y*s.mz.Width+m.coords.X].clear |= m.bit
+	}
+}
+
+// jumpVertical прыгает из from по вертикали в направлении direction, в каждой клетке проверяя горизонтальные прыжки.
+func (s *Solver) jumpVertical(from cells.Coordinates, direction int) (cells.Coordinates, sutils.Cost, bool) {
+	var (
+		total    sutils.Cost
+		scanCost sutils.Cost = -1
+	)
+
+	for current, cell := from, s.vertexAt(from); ; {
+		cost, ok := cell.step(direction)
+		if !ok {
+			return cells.Coordinates{}, 0, false
+		}
+
+		next := adjacent(current, direction)
+		total += cost
+
+		if scanCost != -1 && cost != scanCost || next == s.end {
+			return next, total, true
+		}
+
+		scanCost = cost
+		nextCell := s.vertexAt(next)
+
+		for _, horizontal := range perpendicular(direction) {
+			// Строка впереди покрыта продолжением вертикального прыжка, строка позади - горизонтальными
+			// прыжками из предыдущей клетки: из промежуточной их выполняет этот прыжок, из from - она сама.
+			var bounds [2]bound
+
+			for i, vertical := range perpendicular(horizontal) {
+				if vertical == direction {
+					bounds[i] = nextCell.bound(vertical)
+				} else {
+					bounds[i] = bound{value: -cost, ok: true}
+				}
+			}
+
+			if _, _, found := s.jumpHorizontal(next, horizontal, bounds); found {
+				return next, total, true
+			}
+		}
+
+		current, cell = next, nextCell
+	}
+}
+
+// vertexAt возвращает клетку coords; для координат за границами лабиринта возвращается клетка без переходов.
+func (s *Solver) vertexAt(coords cells.Coordinates) *vertex {
+	if uint(coords.X) >= uint(s.mz.Width) || uint(coords.Y) >= uint(s.mz.Height) {
+		return &vertex{}
+	}
+
+	return &s.grid[coords.Y*s.mz.Width+coords.X]
+}
+
+// estimate возвращает эвристическую оценку пути от coords до конца. Она масштабируется наименьшей стоимостью
+// перехода в самом лабиринте, а не в модели: на однородной местности дорогого типа оценка остаётся точной.
+func (s *Solver) estimate(coords cells.Coordinates) sutils.Cost {
+	return s.floor * sutils.Cost(astar.Manhattan(coords, s.end))
+}
+
+// restorePath восстанавливает путь от start до target, заполняя клетки между последовательными точками прыжка.
+func (s *Solver) restorePath(start, target cells.Coordinates) []cells.Coordinates {
+	path := []cells.Coordinates{target}
+
+	for current := target; current != start; current = s.parents[current] {
+		parent := s.parents[current]
+		back := directionOf(current, parent)
+
+		for coords := current; coords != parent; {
+			coords = adjacent(coords, back)
+			path = append(path, coords)
+		}
+	}
+
+	slices.Reverse(path)
+
+	return path
+}
+
+// prepare подготавливает Solver для исполнения Solve.
+func (s *Solver) prepare(mz maze.Maze, end cells.Coordinates) {
+	clear(s.dist)
+	clear(s.parents)
+	clear(s.closed)
+
+	s.sync(mz)
+
+	s.explored = 0
+	s.end = end
+	s.mz = mz
+}
+
+// sync сверяет сохранённые типы и переходы клеток с mz и пересчитывает стоимости переходов, если лабиринт изменился.
+func (s *Solver) sync(mz maze.Maze) {
+	changed := len(s.grid) != mz.Height*mz.Width || s.mz.Width != mz.Width
+	if changed {
+		s.grid = make([]vertex, mz.Height*mz.Width)
+	}
+
+	for coords, cell := range mz.Cells {
+		vertex := &s.grid[coords.Y*mz.Width+coords.X]
+		vertex.clear = 0
+
+		var links uint8
+
+		for _, next := range cell.Transitions {
+			links |= 1 << directionOf(coords, next)
+		}
+
+		if vertex.kind != cell.Type || vertex.links != links {
+			vertex.kind = cell.Type
+			vertex.links = links
+			changed = true
+		}
+	}
+
+	if !changed {
+		return
+	}
+
+	// Тип клетки влияет и на стоимости переходов в неё из соседних клеток, поэтому пересчитываются все стоимости.
+	s.floor = 0
+
+	for i := range s.grid {
+		s.load(mz, cells.Coordinates{X: i % mz.Width, Y: i / mz.Width}, &s.grid[i])
+	}
+}
+
+// load вычисляет стоимости переходов из клетки coords лабиринта mz и обновляет наименьшую из них.
+func (s *Solver) load(mz maze.Maze, coords cells.Coordinates, vertex *vertex) {
+	for d := range gutils.Dx {
+		if vertex.links&(1<<d) == 0 {
+			continue
+		}
+
+		vertex.costs[d] = s.costs.Step(mz, coords, adjacent(coords, d))
+
+		if s.floor == 0 || vertex.costs[d] < s.floor {
+			s.floor = vertex.costs[d]
+		}
+	}
+}
+
+// perpendicular возвращает два направления, перпендикулярных direction.
+func perpendicular(direction int) [2]int {
+	if isVertical(direction) {
+		return [2]int{west, east}
+	}
+
+	return [2]int{north, south}
+}
+
+// isStronger возвращает true, если граница a не слабее b, то есть вынуждает соседей не чаще b, иначе false.
+func isStronger(a, b bound) bool {
+	return !b.ok || a.ok && a.value <= b.value
+}
+
+// clearBit возвращает бит маски vertex.clear для горизонтального прыжка в направлении horizontal,
+// выполняемого вертикальным прыжком в направлении vertical.
+func clearBit(horizontal, vertical int) uint8 {
+	return 1 << (horizontal/east*2 + vertical - north)
+}
+
+// isVertical возвращает true, если direction ведёт по вертикали, иначе false.
+func isVertical(direction int) bool {
+	return direction == north || direction == south
+}
+
+// directionOf возвращает направление из from в клетку to, лежащую с ней в одной строке или одном столбце.
+func directionOf(from, to cells.Coordinates) int {
+	switch {
+	case to.X < from.X:
+		return west
+	case to.Y < from.Y:
+		return north
+	case to.Y > from.Y:
+		return south
+	default:
+		return east
+	}
+}
+
+// adjacent возвращает соседа coords в направлении direction.
+func adjacent(coords cells.Coordinates, direction int) cells.Coordinates {
+	return cells.Coordinates{X: coords.X + gutils.Dx[direction], Y: coords.Y + gutils.Dy[direction]}
+}
+
+// bound - верхняя граница разности стоимостей путей до соседней клетки и до текущей клетки прыжка.
+type bound struct {
+	value sutils.Cost
+	ok    bool // false, если соседняя клетка не покрыта.
+}
+
+// vertex - клетка лабиринта, прочитанная для прыжков.
+type vertex struct {
+	kind  cells.Type     // Тип клетки.
+	links uint8          // Битовая маска направлений, в которых из клетки есть переход.
+	costs [4]sutils.Cost // Стоимости переходов по направлениям.
+	clear uint8          // Битовая маска горизонтальных прыжков из клетки, которые заведомо ничего не найдут.
+}
+
+// step возвращает стоимость перехода из клетки в направлении direction; если перехода нет, возвращается false.
+func (v *vertex) step(direction int) (sutils.Cost, bool) {
+	return v.costs[direction], v.links>>direction&1 != 0
+}
+
+// bound возвращает границу для соседней клетки в направлении direction, в которую клетка переходит сама.
+func (v *vertex) bound(direction int) bound {
+	cost, ok := v.step(direction)
+
+	return bound{value: cost, ok: ok}
+}
+
+// mark - клетка, пройденная горизонтальным прыжком, и бит маски vertex.clear, который она получит при неудаче прыжка.
+type mark struct {
+	coords cells.Coordinates
+	bit    uint8
+}
+
+// item - запись кучи.
+type item struct {
+	coords   cells.Coordinates
+	dist     sutils.Cost
+	priority sutils.Cost // Оценка пути плюс эвристическая оценка остатка пути.
+}
+
+// nodes реализует интерфейс heap.Interface, описанный в container/heap; при равных приоритетах
+// первыми достаются точки с большей оценкой пути, то есть более близкие к концу.
+type nodes []item
+
+func (n nodes) Len() int { return len(n) }
+
+func (n nodes) Less(i, j int) bool {
+	return n[i].priority < n[j].priority || n[i].priority == n[j].priority && n[i].dist > n[j].dist
+}
+
+func (n nodes) Swap(i, j int) { n[i], n[j] = n[j], n[i] }
+
+func (n *nodes) Push(x any) { *n = append(*n, x.(item)) }
+
+func (n *nodes) Pop() any {
+	old := *n
+	k := len(old)
+	it := old[k-1]
+	*n = old[:k-1]
+
+	return it
+}
@@ -0,0 +1,398 @@
+package jps_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/cave"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/gutils"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/prim"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/dijkstra"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/jps"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils/sutilstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJPSSolverSolve(t *testing.T) {
+	type args struct {
+		mz    maze.Maze
+		start cells.Coordinates
+		end   cells.Coordinates
+	}
+
+	tests := []struct {
+		name         string
+		args         args
+		expected     []cells.Coordinates
+		expectedCost sutils.Cost
+		expectedErr  error
+	}{
+		{
+			name: "start is a wall",
+			args: args{
+				mz:    maze.New(2, 2),
+				start: cells.Coordinates{X: 0, Y: 0},
+				end:   cells.Coordinates{X: 1, Y: 1},
+			},
+			expectedErr: sutils.ErrMaskedCell,
+		},
+		{
+			name: "end is out of bounds",
+			args: args{
+				mz:    newOpenMaze(3, 3, nil),
+				start: cells.Coordinates{X: 0, Y: 0},
+				end:   cells.Coordinates{X: 3, Y: 0},
+			},
+			expectedErr: sutils.ErrOutOfBounds,
+		},
+		{
+			name: "path doesn`t exist",
+			args: args{
+				mz:    newOpenMaze(3, 3, func(x, _ int) bool { return x == 1 }),
+				start: cells.Coordinates{X: 0, Y: 0},
+				end:   cells.Coordinates{X: 2, Y: 2},
+			},
+			expectedErr: sutils.ErrUnreachable,
+		},
+		{
+			name: "path to itself",
+			args: args{
+				mz:    newOpenMaze(3, 3, nil),
+				start: cells.Coordinates{X: 1, Y: 1},
+				end:   cells.Coordinates{X: 1, Y: 1},
+			},
+			expected:     []cells.Coordinates{{X: 1, Y: 1}},
+			expectedCost: 2,
+		},
+		{
+			name: "path is a straight line in open area",
+			args: args{
+				mz:    newOpenMaze(5, 5, nil),
+				start: cells.Coordinates{X: 0, Y: 2},
+				end:   cells.Coordinates{X: 4, Y: 2},
+			},
+			expected:     []cells.Coordinates{{X: 0, Y: 2}, {X: 1, Y: 2}, {X: 2, Y: 2}, {X: 3, Y: 2}, {X: 4, Y: 2}},
+			expectedCost: 10,
+		},
+		{
+			name: "path goes around the wall",
+			args: args{
+				mz:    newOpenMaze(5, 5, func(x, y int) bool { return x == 2 && y < 4 }),
+				start: cells.Coordinates{X: 0, Y: 0},
+				end:   cells.Coordinates{X: 4, Y: 0},
+			},
+			expectedCost: 26,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := jps.NewSolver(sutils.DefaultCosts()).Solve(tt.args.mz, tt.args.start, tt.args.end)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+
+			if tt.expected != nil {
+				assert.Equal(t, tt.expected, result.Path)
+			}
+
+			assert.True(t, sutilstest.IsPathValid(tt.args.mz, result.Path, tt.args.start, tt.args.end))
+			assert.Equal(t, tt.expectedCost, result.Cost)
+		})
+	}
+}
+
+func TestJPSSolverSolveMatchesDijkstra(t *testing.T) {
+	tolls, err := sutils.NewCosts(map[cells.Type]sutils.Cost{cells.LightedPass: 1, cells.Pass: 3}, false,
+		map[sutils.Edge]sutils.Cost{
+			{From: cells.Coordinates{X: 5, Y: 5}, To: cells.Coordinates{X: 6, Y: 5}}: 4,
+			{From: cells.Coordinates{X: 6, Y: 6}, To: cells.Coordinates{X: 6, Y: 7}}: 2,
+		})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		mz    func(t *testing.T) maze.Maze
+		costs sutils.CostModel
+	}{
+		{
+			name:  "open area with obstacles",
+			mz:    func(t *testing.T) maze.Maze { return newRandomMaze(t, 24, 24, 20, false) },
+			costs: sutils.DefaultCosts(),
+		},
+		{
+			name:  "mixed terrain",
+			mz:    func(t *testing.T) maze.Maze { return newRandomMaze(t, 24, 24, 10, true) },
+			costs: sutils.DefaultCosts(),
+		},
+		{
+			name:  "mixed terrain with tolls",
+			mz:    func(t *testing.T) maze.Maze { return newRandomMaze(t, 24, 24, 10, true) },
+			costs: tolls,
+		},
+		{
+			name:  "rooms",
+			mz:    func(*testing.T) maze.Maze { return newRoomsMaze(36, 12) },
+			costs: sutils.DefaultCosts(),
+		},
+		{
+			name:  "cave",
+			mz:    func(t *testing.T) maze.Maze { return newCave(t, 40, 40, false) },
+			costs: sutils.DefaultCosts(),
+		},
+		{
+			name: "perfect maze",
+			mz: func(t *testing.T) maze.Maze {
+				mz, err := prim.NewGenerator().Generate(21, 21)
+				require.NoError(t, err)
+
+				return mz
+			},
+			costs: sutils.DefaultCosts(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := jps.NewSolver(tt.costs)
+
+			for range 5 {
+				mz := tt.mz(t)
+				passages := passagesOf(mz)
+
+				for range 10 {
+					start, end := randomPair(t, passages)
+					checkSolve(t, s, tt.costs, mz, start, end)
+				}
+			}
+		})
+	}
+}
+
+func TestJPSSolverSolveAfterMazeChange(t *testing.T) {
+	s := jps.NewSolver(sutils.DefaultCosts())
+	mz := newOpenMaze(16, 16, nil)
+	start, end := cells.Coordinates{X: 0, Y: 0}, cells.Coordinates{X: 15, Y: 15}
+
+	checkSolve(t, s, sutils.DefaultCosts(), mz, start, end)
+
+	// Решатель должен заметить изменения лабиринта на месте: новый тип клеток и разорванные переходы.
+	for y := range 15 {
+		coords := cells.Coordinates{X: 8, Y: y}
+		mz.Cells[coords].Type = cells.LightedPass
+
+		unlink(mz, coords, cells.Coordinates{X: 7, Y: y})
+	}
+
+	checkSolve(t, s, sutils.DefaultCosts(), mz, start, end)
+}
+
+func TestJPSSolverSolveExploresLessOnRooms(t *testing.T) {
+	mz := newRoomsMaze(60, 20)
+	start, end := cells.Coordinates{X: 0, Y: 0}, cells.Coordinates{X: 58, Y: 58}
+
+	expected, err := dijkstra.NewSolver(sutils.DefaultCosts()).Solve(mz, start, end)
+	require.NoError(t, err)
+
+	result, err := jps.NewSolver(sutils.DefaultCosts()).Solve(mz, start, end)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected.Cost, result.Cost)
+	assert.Less(t, result.Explored*10, expected.Explored)
+}
+
+// BenchmarkSolversOnOpenMaps сравнивает поиск с прыжками с алгоритмом Дейкстры на картах с открытыми областями
+// одной стоимости между самыми удалёнными друг от друга по диагонали проходами.
+func BenchmarkSolversOnOpenMaps(b *testing.B) {
+	maps := []struct {
+		name string
+		mz   maze.Maze
+	}{
+		{name: "rooms", mz: newRoomsMaze(200, 40)},
+		{name: "open", mz: newOpenMaze(200, 200, func(x, y int) bool { return (x*7+y*13)%97 == 0 })},
+		{name: "cave", mz: newCave(b, 200, 200, true)},
+	}
+
+	solvers := []struct {
+		name string
+		new  func() solver
+	}{
+		{name: "jps", new: func() solver { return jps.NewSolver(sutils.DefaultCosts()) }},
+		{name: "dijkstra", new: func() solver { return dijkstra.NewSolver(sutils.DefaultCosts()) }},
+	}
+
+	for _, m := range maps {
+		passages := passagesOf(m.mz)
+		start, end := passages[0], passages[len(passages)-1]
+
+		for _, sv := range solvers {
+			b.Run(m.name+"/"+sv.name, func(b *testing.B) {
+				s := sv.new()
+
+				for range b.N {
+					_, err := s.Solve(m.mz, start, end)
+					require.NoError(b, err)
+				}
+			})
+		}
+	}
+}
+
+type solver interface {
+	Solve(mz maze.Maze, start, end cells.Coordinates) (sutils.Result, error)
+}
+
+// checkSolve проверяет, что s находит в mz путь той же стоимости, что и алгоритм Дейкстры, или ту же ошибку.
+func checkSolve(t *testing.T, s solver, costs sutils.CostModel, mz maze.Maze, start, end cells.Coordinates) {
+	t.Helper()
+
+	expected, expectedErr := dijkstra.NewSolver(costs).Solve(mz, start, end)
+	result, err := s.Solve(mz, start, end)
+
+	if expectedErr != nil {
+		require.ErrorIs(t, err, sutils.ErrUnreachable)
+		return
+	}
+
+	require.NoError(t, err)
+	require.True(t, sutilstest.IsPathValid(mz, result.Path, start, end))
+	require.Equal(t, expected.Cost, result.Cost, "start %v, end %v", start, end)
+	require.Equal(t, sutils.PathCost(costs, mz, result.Path), result.Cost)
+}
+
+// newOpenMaze возвращает лабиринт из обычных проходов, в котором связаны все соседние проходы;
+// клетки, для которых isWall возвращает true, остаются стенами.
+func newOpenMaze(height, width int, isWall func(x, y int) bool) maze.Maze {
+	mz := maze.New(height, width)
+
+	for coords, cell := range mz.Cells {
+		if isWall == nil || !isWall(coords.X, coords.Y) {
+			cell.Type = cells.Pass
+		}
+	}
+
+	linkPassages(mz)
+
+	return mz
+}
+
+// newRandomMaze возвращает открытый лабиринт, в котором каждая клетка становится стеной с вероятностью
+// wallChance процентов; при mixed = true проходы получают случайный тип.
+func newRandomMaze(t *testing.T, height, width, wallChance int, mixed bool) maze.Maze {
+	t.Helper()
+
+	mz := maze.New(height, width)
+
+	for _, cell := range mz.Cells {
+		n, err := gutils.GetRandomInt(100)
+		require.NoError(t, err)
+
+		if n < wallChance {
+			continue
+		}
+
+		cell.Type = cells.Pass
+
+		if mixed {
+			cell.Type, err = gutils.GetRandomSignificantType()
+			require.NoError(t, err)
+		}
+	}
+
+	linkPassages(mz)
+
+	return mz
+}
+
+// newRoomsMaze возвращает квадратный лабиринт из комнат со стороной roomSize - 1, разделённых стенами
+// с дверями посередине каждой стены.
+func newRoomsMaze(size, roomSize int) maze.Maze {
+	return newOpenMaze(size, size, func(x, y int) bool {
+		isWall := x%roomSize == roomSize-1 || y%roomSize == roomSize-1
+		isDoor := x%roomSize == roomSize/2 || y%roomSize == roomSize/2
+
+		return isWall && !isDoor
+	})
+}
+
+// newCave возвращает пещеру; при uniform = true все её проходы получают один тип.
+func newCave(tb testing.TB, height, width int, uniform bool) maze.Maze {
+	tb.Helper()
+
+	mz, err := cave.NewGenerator().Generate(height, width)
+	require.NoError(tb, err)
+
+	if uniform {
+		for _, cell := range mz.Cells {
+			if cell.Type != cells.Wall {
+				cell.Type = cells.Pass
+			}
+		}
+	}
+
+	return mz
+}
+
+// linkPassages связывает переходами все пары соседних проходов mz.
+func linkPassages(mz maze.Maze) {
+	for coords, cell := range mz.Cells {
+		if cell.Type == cells.Wall {
+			continue
+		}
+
+		for _, next := range []cells.Coordinates{{X: coords.X + 1, Y: coords.Y}, {X: coords.X, Y: coords.Y + 1}} {
+			if nextCell, ok := mz.Cells[next]; ok && nextCell.Type != cells.Wall {
+				cell.Transitions = append(cell.Transitions, next)
+				nextCell.Transitions = append(nextCell.Transitions, coords)
+			}
+		}
+	}
+}
+
+// unlink разрывает переходы между клетками a и b.
+func unlink(mz maze.Maze, a, b cells.Coordinates) {
+	mz.Cells[a].Transitions = slices.DeleteFunc(mz.Cells[a].Transitions, func(c cells.Coordinates) bool { return c == b })
+	mz.Cells[b].Transitions = slices.DeleteFunc(mz.Cells[b].Transitions, func(c cells.Coordinates) bool { return c == a })
+}
+
+// passagesOf возвращает проходы mz, упорядоченные по сумме координат.
+func passagesOf(mz maze.Maze) []cells.Coordinates {
+	var passages []cells.Coordinates
+
+	for coords, cell := range mz.Cells {
+		if cell.Type != cells.Wall {
+			passages = append(passages, coords)
+		}
+	}
+
+	slices.SortFunc(passages, func(a, b cells.Coordinates) int {
+		if a.X+a.Y != b.X+b.Y {
+			return a.X + a.Y - b.X - b.Y
+		}
+
+		return a.Y - b.Y
+	})
+
+	return passages
+}
+
+// randomPair возвращает два случайных прохода из passages.
+func randomPair(t *testing.T, passages []cells.Coordinates) (cells.Coordinates, cells.Coordinates) {
+	t.Helper()
+
+	i, err := gutils.GetRandomInt(len(passages))
+	require.NoError(t, err)
+
+	j, err := gutils.GetRandomInt(len(passages))
+	require.NoError(t, err)
+
+	return passages[i], passages[j]
+}
@@ -0,0 +1,305 @@
+package kshortest
+
+import (
+	"math/big"
+	"slices"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils"
+)
+
+// edge - направленный переход между клетками.
+type edge struct {
+	from cells.Coordinates
+	to   cells.Coordinates
+}
+
+// candidate - путь-кандидат алгоритма Йена вместе с его стоимостью.
+type candidate struct {
+	path []cells.Coordinates
+	cost sutils.Cost
+}
+
+// Solver - структура решателя, перечисляющего k самых дешёвых путей без петель по алгоритму Йена.
+type Solver struct {
+	costs           sutils.CostModel                  // Модель стоимости путей.
+	k               int                               // Наибольшее количество перечисляемых путей.
+	dist            map[cells.Coordinates]sutils.Cost // Хранит для каждой достигнутой вершины лучшую известную оценку пути.
+	heap            sutils.Heap                       // Куча минимумов, содержащая вершины и их оценку пути.
+	predecessors    sutils.Predecessors               // Хранит для каждой вершины информацию о её предшественниках.
+	height, width   int                               // Размеры лабиринта, под которые выделен predecessors.
+	blockedVertices map[cells.Coordinates]struct{}    // Вершины, запрещённые в текущем поиске ответвления.
+	blockedEdges    map[edge]struct{}                 // Переходы, запрещённые в текущем поиске ответвления.
+	explored        int                               // Количество вершин, раскрытых всеми поисками.
+}
+
+// NewSolver возвращает указатель на инициализированный Solver, перечисляющий не более k путей
+// со стоимостью в модели costs; k меньше единицы считается равным единице.
+func NewSolver(costs sutils.CostModel, k int) *Solver {
+	return &Solver{
+		costs:           costs,
+		k:               max(k, 1),
+		dist:            make(map[cells.Coordinates]sutils.Cost),
+		blockedVertices: make(map[cells.Coordinates]struct{}),
+		blockedEdges:    make(map[edge]struct{}),
+	}
+}
+
+// Solve находит самый дешёвый путь от start до end в mz и возвращает его вместе со статистикой поиска.
+func (s *Solver) Solve(mz maze.Maze, start, end cells.Coordinates) (sutils.Result, error) {
+	err := sutils.Validate(mz, start, end)
+	if err != nil {
+		return sutils.Result{}, err
+	}
+
+	begin := time.Now()
+
+	s.explored = 0
+
+	return sutils.NewResult(s.costs, mz, s.shortest(mz, start, end), s.explored, time.Since(begin))
+}
+
+// Paths находит не более k самых дешёвых путей без петель от start до end в mz в порядке неубывания стоимости.
+// Explored и Duration каждого результата накоплены с начала перечисления до нахождения этого пути.
+func (s *Solver) Paths(mz maze.Maze, start, end cells.Coordinates) ([]sutils.Result, error) {
+	// Суть алгоритма Йена:
+	//
+	// Алгоритм:
+	// 1) Первый путь - кратчайший путь от start до end.
+	// 2) Для каждой клетки spur последнего найденного пути, кроме end:
+	//   2.1) Корнем считается часть пути от start до spur.
+	//   2.2) Запрещаются клетки корня, кроме spur, и переходы из spur, которыми продолжаются уже найденные пути
+	//        с тем же корнем.
+	//   2.3) Кратчайший путь от spur до end с учётом запретов, присоединённый к корню, становится кандидатом.
+	// 3) Самый дешёвый кандидат становится следующим путём.
+	//
+	// Пункты 2, 3 повторяются, пока не найдено k путей или не закончились кандидаты.
+	err := sutils.Validate(mz, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	begin := time.Now()
+
+	s.explored = 0
+
+	first, err := sutils.NewResult(s.costs, mz, s.shortest(mz, start, end), s.explored, time.Since(begin))
+	if err != nil {
+		return nil, err
+	}
+
+	found := [][]cells.Coordinates{first.Path}
+	results := []sutils.Result{first}
+
+	var candidates []candidate
+
+	for len(found) < s.k {
+		previous := found[len(found)-1]
+
+		for i := 0; i < len(previous)-1; i++ {
+			root := previous[:i+1]
+
+			s.block(root, found)
+
+			spurPath := s.shortest(mz, previous[i], end)
+			if len(spurPath) == 0 {
+				continue
+			}
+
+			path := append(slices.Clone(root[:i]), spurPath...)
+			if !containsCandidate(candidates, path) && !containsPath(found, path) {
+				candidates = append(candidates, candidate{path: path, cost: sutils.PathCost(s.costs, mz, path)})
+			}
+		}
+
+		clear(s.blockedVertices)
+		clear(s.blockedEdges)
+
+		if len(candidates) == 0 {
+			break
+		}
+
+		best := 0
+		for i := range candidates {
+			if candidates[i].cost < candidates[best].cost {
+				best = i
+			}
+		}
+
+		cheapest := candidates[best].path
+		candidates = slices.Delete(candidates, best, best+1)
+
+		result, _ := sutils.NewResult(s.costs, mz, cheapest, s.explored, time.Since(begin)) // Путь не пуст - ошибки нет.
+
+		found = append(found, cheapest)
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// CountOptimal возвращает количество различных путей от start до end в mz, стоимость которых минимальна.
+func (s *Solver) CountOptimal(mz maze.Maze, start, end cells.Coordinates) (*big.Int, error) {
+	ps, err := s.optimalDAG(mz, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	return sutils.CountPaths(start, end, ps), nil
+}
+
+// AllOptimal возвращает не более limit различных путей от start до end в mz, стоимость которых минимальна.
+func (s *Solver) AllOptimal(mz maze.Maze, start, end cells.Coordinates, limit int) ([][]cells.Coordinates, error) {
+	ps, err := s.optimalDAG(mz, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	return sutils.RestorePaths(start, end, ps, limit), nil
+}
+
+// optimalDAG находит алгоритмом Дейкстры для каждой вершины всех предшественников на кратчайших путях от start.
+func (s *Solver) optimalDAG(mz maze.Maze, start, end cells.Coordinates) (sutils.PredecessorSets, error) {
+	// Все предшественники вершины на кратчайших путях имеют строго меньшую оценку пути (стоимости переходов положительны),
+	// поэтому к моменту извлечения end из кучи его множество предшественников уже полно.
+	err := sutils.Validate(mz, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	s.prepare(mz.Height, mz.Width)
+
+	ps := sutils.PredecessorSets{}
+	closed := make(map[cells.Coordinates]struct{})
+
+	s.dist[start] = s.costs.Start(mz, start)
+	s.heap.Push(sutils.Item{Vertex: start, Weight: s.dist[start]})
+
+	for s.heap.Len() != 0 {
+		vertex1 := s.heap.Pop().Vertex
+
+		if _, ok := closed[vertex1]; ok { // Устаревшая запись.
+			continue
+		}
+
+		closed[vertex1] = struct{}{}
+
+		if vertex1 == end {
+			return ps, nil
+		}
+
+		for _, vertex2 := range mz.Cells[vertex1].Transitions {
+			newDist := s.dist[vertex1] + s.costs.Step(mz, vertex1, vertex2)
+
+			oldDist, ok := s.dist[vertex2]
+
+			switch {
+			case !ok || newDist < oldDist: // Найден более дешёвый путь - прежние предшественники не нужны.
+				s.dist[vertex2] = newDist
+				s.heap.Push(sutils.Item{Vertex: vertex2, Weight: newDist})
+				ps[vertex2] = []cells.Coordinates{vertex1}
+			case newDist == oldDist: // Найден ещё один путь той же стоимости.
+				ps[vertex2] = append(ps[vertex2], vertex1)
+			}
+		}
+	}
+
+	return nil, sutils.ErrUnreachable
+}
+
+// shortest находит алгоритмом Дейкстры кратчайший путь от start до end в обход запрещённых вершин и переходов.
+func (s *Solver) shortest(mz maze.Maze, start, end cells.Coordinates) []cells.Coordinates {
+	s.prepare(mz.Height, mz.Width)
+
+	closed := make(map[cells.Coordinates]struct{})
+
+	s.dist[start] = s.costs.Start(mz, start)
+	s.heap.Push(sutils.Item{Vertex: start, Weight: s.dist[start]})
+
+	for s.heap.Len() != 0 {
+		vertex1 := s.heap.Pop().Vertex
+
+		if _, ok := closed[vertex1]; ok { // Устаревшая запись.
+			continue
+		}
+
+		closed[vertex1] = struct{}{}
+		s.explored++
+
+		if vertex1 == end {
+			break
+		}
+
+		for _, vertex2 := range mz.Cells[vertex1].Transitions {
+			if s.isBlocked(vertex1, vertex2) {
+				continue
+			}
+
+			newDist := s.dist[vertex1] + s.costs.Step(mz, vertex1, vertex2)
+
+			if oldDist, ok := s.dist[vertex2]; !ok || newDist < oldDist {
+				s.dist[vertex2] = newDist
+				s.heap.Push(sutils.Item{Vertex: vertex2, Weight: newDist})
+				s.predecessors[vertex2] = vertex1
+			}
+		}
+	}
+
+	return sutils.RestorePath(start, end, s.predecessors)
+}
+
+// block запрещает клетки корня, кроме последней, и переходы из неё, которыми продолжаются найденные пути с этим корнем.
+func (s *Solver) block(root []cells.Coordinates, found [][]cells.Coordinates) {
+	clear(s.blockedVertices)
+	clear(s.blockedEdges)
+
+	spur := len(root) - 1
+
+	for _, coords := range root[:spur] {
+		s.blockedVertices[coords] = struct{}{}
+	}
+
+	for _, path := range found {
+		if len(path) > spur+1 && slices.Equal(path[:spur+1], root) {
+			s.blockedEdges[edge{from: path[spur], to: path[spur+1]}] = struct{}{}
+		}
+	}
+}
+
+// isBlocked возвращает true, если переход из from в to запрещён, иначе false.
+func (s *Solver) isBlocked(from, to cells.Coordinates) bool {
+	_, vertexBlocked := s.blockedVertices[to]
+	_, edgeBlocked := s.blockedEdges[edge{from: from, to: to}]
+
+	return vertexBlocked || edgeBlocked
+}
+
+// containsCandidate возвращает true, если среди candidates есть путь path, иначе false.
+func containsCandidate(candidates []candidate, path []cells.Coordinates) bool {
+	return slices.ContainsFunc(candidates, func(c candidate) bool { return slices.Equal(c.path, path) })
+}
+
+// containsPath возвращает true, если paths содержит path, иначе false.
+func containsPath(paths [][]cells.Coordinates, path []cells.Coordinates) bool {
+	return slices.ContainsFunc(paths, func(p []cells.Coordinates) bool { return slices.Equal(p, path) })
+}
+
+// prepare подготавливает Solver к очередному поиску. Поиски ответвлений запускаются для каждой клетки пути,
+// поэтому predecessors выделяется заново только при смене размеров лабиринта, а между поисками сбрасываются
+// лишь записи достигнутых вершин - только им поиск назначает предшественников.
+func (s *Solver) prepare(height, width int) {
+	if s.height != height || s.width != width {
+		s.height, s.width = height, width
+		s.predecessors = sutils.NewPredecessors(height, width)
+	}
+
+	for coords := range s.dist {
+		s.predecessors[coords] = cells.Coordinates{X: sutils.MissingX, Y: sutils.MissingY}
+	}
+
+	clear(s.dist)
+
+	s.heap = sutils.New()
+}
@@ -0,0 +1,234 @@
+package kshortest_test
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/cave"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/dijkstra"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/kshortest"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils/sutilstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKShortestSolverPaths(t *testing.T) {
+	type args struct {
+		mz    maze.Maze
+		k     int
+		start cells.Coordinates
+		end   cells.Coordinates
+	}
+
+	tests := []struct {
+		name          string
+		args          args
+		expectedCosts []sutils.Cost
+		expectedCount int64
+		expectedErr   error
+	}{
+		{
+			name: "start is a wall",
+			args: args{
+				mz:    maze.New(2, 2),
+				k:     3,
+				start: cells.Coordinates{X: 0, Y: 0},
+				end:   cells.Coordinates{X: 1, Y: 1},
+			},
+			expectedErr: sutils.ErrMaskedCell,
+		},
+		{
+			name: "ring has exactly two loopless paths",
+			args: args{
+				mz:    sutilstest.NewGridMaze(2, 2),
+				k:     3,
+				start: cells.Coordinates{X: 0, Y: 0},
+				end:   cells.Coordinates{X: 1, Y: 1},
+			},
+			expectedCosts: []sutils.Cost{6, 6},
+			expectedCount: 2,
+		},
+		{
+			name: "open grid has six optimal paths",
+			args: args{
+				mz:    sutilstest.NewGridMaze(3, 3),
+				k:     7,
+				start: cells.Coordinates{X: 0, Y: 0},
+				end:   cells.Coordinates{X: 2, Y: 2},
+			},
+			expectedCosts: []sutils.Cost{10, 10, 10, 10, 10, 10, 14},
+			expectedCount: 6,
+		},
+		{
+			name: "non-positive k enumerates one path",
+			args: args{
+				mz:    sutilstest.NewGridMaze(3, 3),
+				k:     0,
+				start: cells.Coordinates{X: 0, Y: 0},
+				end:   cells.Coordinates{X: 2, Y: 2},
+			},
+			expectedCosts: []sutils.Cost{10},
+			expectedCount: 6,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := kshortest.NewSolver(sutils.DefaultCosts(), tt.args.k)
+
+			results, err := s.Paths(tt.args.mz, tt.args.start, tt.args.end)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+
+			costs := make([]sutils.Cost, 0, len(results))
+			for _, result := range results {
+				costs = append(costs, result.Cost)
+				assert.True(t, sutilstest.IsPathValid(tt.args.mz, result.Path, tt.args.start, tt.args.end))
+			}
+
+			assert.Equal(t, tt.expectedCosts, costs)
+			assert.True(t, areDistinct(results))
+
+			count, err := s.CountOptimal(tt.args.mz, tt.args.start, tt.args.end)
+			require.NoError(t, err)
+
+			assert.Equal(t, big.NewInt(tt.expectedCount), count)
+		})
+	}
+}
+
+func TestKShortestSolverOnCave(t *testing.T) {
+	mz, err := cave.NewGenerator().Generate(32, 32)
+	require.NoError(t, err)
+
+	var passages []cells.Coordinates
+
+	for coords, cell := range mz.Cells {
+		if cell.Type != cells.Wall {
+			passages = append(passages, coords)
+		}
+	}
+
+	start, end := passages[0], passages[len(passages)-1]
+	s := kshortest.NewSolver(sutils.DefaultCosts(), 5)
+
+	expected, err := dijkstra.NewSolver(sutils.DefaultCosts()).Solve(mz, start, end)
+	require.NoError(t, err)
+
+	results, err := s.Paths(mz, start, end)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected.Cost, results[0].Cost)
+	assert.True(t, areDistinct(results))
+
+	for i, result := range results {
+		assert.True(t, sutilstest.IsPathValid(mz, result.Path, start, end))
+
+		if i > 0 {
+			assert.LessOrEqual(t, results[i-1].Cost, result.Cost)
+		}
+	}
+
+	count, err := s.CountOptimal(mz, start, end)
+	require.NoError(t, err)
+
+	optimal, err := s.AllOptimal(mz, start, end, 10)
+	require.NoError(t, err)
+
+	assert.Len(t, optimal, int(min(count.Int64(), 10)))
+
+	for _, path := range optimal {
+		assert.Equal(t, expected.Cost, sutils.PathCost(sutils.DefaultCosts(), mz, path))
+		assert.True(t, sutilstest.IsPathValid(mz, path, start, end))
+	}
+}
+
+func TestKShortestSolverReuse(t *testing.T) {
+	// Один решатель последовательно перечисляет пути в лабиринтах разных размеров,
+	// и предшественники прежних поисков не должны влиять на следующие.
+	split := sutilstest.NewGridMaze(3, 3, cells.Coordinates{X: 1, Y: 0}, cells.Coordinates{X: 1, Y: 1}, cells.Coordinates{X: 1, Y: 2})
+
+	tests := []struct {
+		name          string
+		mz            maze.Maze
+		end           cells.Coordinates
+		expectedCosts []sutils.Cost
+		expectedErr   error
+	}{
+		{
+			name:          "open grid",
+			mz:            sutilstest.NewGridMaze(3, 3),
+			end:           cells.Coordinates{X: 2, Y: 2},
+			expectedCosts: []sutils.Cost{10, 10, 10},
+		},
+		{
+			name:          "smaller ring",
+			mz:            sutilstest.NewGridMaze(2, 2),
+			end:           cells.Coordinates{X: 1, Y: 1},
+			expectedCosts: []sutils.Cost{6, 6},
+		},
+		{
+			name:        "end is cut off",
+			mz:          split,
+			end:         cells.Coordinates{X: 2, Y: 2},
+			expectedErr: sutils.ErrUnreachable,
+		},
+		{
+			name:          "corridor after unreachable end",
+			mz:            sutilstest.NewGridMaze(1, 4),
+			end:           cells.Coordinates{X: 3, Y: 0},
+			expectedCosts: []sutils.Cost{8},
+		},
+	}
+
+	s := kshortest.NewSolver(sutils.DefaultCosts(), 3)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := cells.Coordinates{X: 0, Y: 0}
+
+			results, err := s.Paths(tt.mz, start, tt.end)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+
+			costs := make([]sutils.Cost, 0, len(results))
+			for _, result := range results {
+				costs = append(costs, result.Cost)
+				assert.True(t, sutilstest.IsPathValid(tt.mz, result.Path, start, tt.end))
+			}
+
+			assert.Equal(t, tt.expectedCosts, costs)
+		})
+	}
+}
+
+// areDistinct проверяет, что все пути результатов попарно различны.
+func areDistinct(results []sutils.Result) bool {
+	seen := make(map[string]struct{}, len(results))
+
+	for _, result := range results {
+		key := fmt.Sprint(result.Path)
+
+		if _, ok := seen[key]; ok {
+			return false
+		}
+
+		seen[key] = struct{}{}
+	}
+
+	return true
+}
@@ -0,0 +1,359 @@
+package multiagent
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils"
+)
+
+var (
+	// ErrSharedCell возвращается, если у двух агентов совпадают начала или концы.
+	ErrSharedCell = errors.New("agents share start or end cell")
+	// ErrNoPlan возвращается, если расписание без столкновений не найдено.
+	ErrNoPlan = errors.New("collision-free plan wasn`t found")
+)
+
+// Agent описывает агента его началом и концом.
+type Agent struct {
+	Start cells.Coordinates
+	End   cells.Coordinates
+}
+
+// Plan содержит расписание движения агентов.
+type Plan struct {
+	Paths    [][]cells.Coordinates // Paths[i][t] - клетка агента i на шаге t; дошедший агент стоит на конце.
+	Makespan int                   // Количество шагов, за которое до концов доходят все агенты.
+	Explored int                   // Количество состояний (клетка, шаг), раскрытых решателем.
+	Duration time.Duration         // Время, затраченное на поиск.
+}
+
+// Solver - структура решателя, планирующего пути нескольких агентов без столкновений по приоритетам.
+type Solver struct {
+	costs    sutils.CostModel          // Модель стоимости путей, используемая для результата Solve.
+	occupied map[state]struct{}        // Клетки, занятые уже спланированными агентами, по шагам.
+	moves    map[move]struct{}         // Переходы уже спланированных агентов по шагам.
+	latest   map[cells.Coordinates]int // Последний шаг, на котором клетка занята спланированным агентом.
+	parked   map[cells.Coordinates]int // Шаг, начиная с которого в клетке навсегда стоит дошедший агент.
+	distance map[cells.Coordinates]int // Количество шагов от клетки до конца текущего агента без учёта других.
+	explored int                       // Количество состояний, раскрытых во всех попытках.
+	mz       maze.Maze
+}
+
+// NewSolver возвращает указатель на инициализированный Solver со стоимостью путей в модели costs.
+func NewSolver(costs sutils.CostModel) *Solver {
+	return &Solver{
+		costs:    costs,
+		occupied: make(map[state]struct{}),
+		moves:    make(map[move]struct{}),
+		latest:   make(map[cells.Coordinates]int),
+		parked:   make(map[cells.Coordinates]int),
+		distance: make(map[cells.Coordinates]int),
+	}
+}
+
+// Solve находит путь от start до end в mz для единственного агента, то есть путь с наименьшим количеством шагов,
+// и возвращает его вместе со статистикой поиска.
+func (s *Solver) Solve(mz maze.Maze, start, end cells.Coordinates) (sutils.Result, error) {
+	plan, err := s.SolveAgents(mz, []Agent{{Start: start, End: end}})
+	if err != nil {
+		return sutils.Result{}, err
+	}
+
+	return sutils.NewResult(s.costs, mz, plan.Paths[0], plan.Explored, plan.Duration)
+}
+
+// SolveAgents находит для agents в mz пути, на которых никакие два агента не оказываются в одной клетке
+// на одном шаге и не меняются клетками за один шаг. За шаг агент переходит в соседнюю клетку или стоит на месте.
+func (s *Solver) SolveAgents(mz maze.Maze, agents []Agent) (Plan, error) {
+	err := validate(mz, agents)
+	if err != nil {
+		return Plan{}, err
+	}
+
+	begin := time.Now()
+
+	s.explored = 0
+	s.mz = mz
+
+	for i, agent := range agents {
+		s.computeDistances(agent.End)
+
+		if _, ok := s.distance[agent.Start]; !ok {
+			return Plan{}, fmt.Errorf("agent %d: %w", i, sutils.ErrUnreachable)
+		}
+	}
+
+	// Суть приоритетного планирования:
+	//
+	// Агенты планируются по одному в порядке приоритета. Путь очередного агента ищется A* в пространстве
+	// (клетка, шаг) в обход клеток и переходов, занятых агентами с более высоким приоритетом; дошедший
+	// агент навсегда занимает свой конец.
+	//
+	// Приоритетное планирование неполно: если путь очередного агента не найден, он поднимается в начало
+	// очереди, и планирование повторяется. Попыток не больше, чем агентов.
+	order := make([]int, len(agents))
+	for i := range order {
+		order[i] = i
+	}
+
+	for range len(agents) {
+		paths, failed := s.planAll(agents, order)
+		if failed == -1 {
+			return newPlan(paths, s.explored, time.Since(begin)), nil
+		}
+
+		raised := order[failed]
+		order = append([]int{raised}, slices.Delete(order, failed, failed+1)...)
+	}
+
+	return Plan{}, ErrNoPlan
+}
+
+// planAll планирует агентов в порядке order и возвращает их пути по индексам agents;
+// если путь агента не найден, возвращается его позиция в order, иначе -1.
+func (s *Solver) planAll(agents []Agent, order []int) ([][]cells.Coordinates, int) {
+	clear(s.occupied)
+	clear(s.moves)
+	clear(s.latest)
+	clear(s.parked)
+
+	paths := make([][]cells.Coordinates, len(agents))
+
+	for position, i := range order {
+		path := s.planAgent(agents[i])
+		if path == nil {
+			return nil, position
+		}
+
+		s.reserve(path)
+		paths[i] = path
+	}
+
+	return paths, -1
+}
+
+// planAgent находит путь agent с наименьшим количеством шагов в обход занятых клеток и переходов;
+// если путь не найден, возвращается nil.
+func (s *Solver) planAgent(agent Agent) []cells.Coordinates {
+	s.computeDistances(agent.End)
+
+	// Дальше последнего занятого шага другие агенты лишь стоят на концах, и лабиринт не меняется,
+	// поэтому путь, если он есть, укладывается в этот шаг плюс количество клеток.
+	horizon := len(s.mz.Cells)
+	for _, t := range s.latest {
+		horizon = max(horizon, t+len(s.mz.Cells))
+	}
+
+	origin := state{coords: agent.Start, t: 0}
+	predecessors := make(map[state]state)
+	closed := make(map[state]struct{})
+
+	open := &states{}
+	heap.Push(open, item{state: origin, priority: s.distance[agent.Start]})
+
+	for open.Len() != 0 {
+		current := heap.Pop(open).(item).state
+
+		if _, ok := closed[current]; ok {
+			continue
+		}
+
+		closed[current] = struct{}{}
+		s.explored++
+
+		if current.coords == agent.End && s.canPark(current) {
+			return restorePath(origin, current, predecessors)
+		}
+
+		if current.t == horizon {
+			continue
+		}
+
+		// Агент может переходить в соседние клетки или стоять на месте.
+		for _, next := range append(slices.Clone(s.mz.Cells[current.coords].Transitions), current.coords) {
+			successor := state{coords: next, t: current.t + 1}
+
+			if _, ok := s.distance[next]; !ok || !s.isFree(current, successor) {
+				continue
+			}
+
+			if _, ok := closed[successor]; ok {
+				continue
+			}
+
+			if _, ok := predecessors[successor]; !ok {
+				predecessors[successor] = current
+			}
+
+			heap.Push(open, item{state: successor, priority: successor.t + s.distance[next]})
+		}
+	}
+
+	return nil
+}
+
+// computeDistances вычисляет поиском в ширину количество шагов от каждой клетки до end; оно служит точной
+// эвристикой A* в отсутствие других агентов. Клетки, из которых end недостижим, в distance не попадают.
+func (s *Solver) computeDistances(end cells.Coordinates) {
+	clear(s.distance)
+
+	s.distance[end] = 0
+	queue := []cells.Coordinates{end}
+
+	for len(queue) != 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, next := range s.mz.Cells[current].Transitions {
+			if _, ok := s.distance[next]; !ok {
+				s.distance[next] = s.distance[current] + 1
+				queue = append(queue, next)
+			}
+		}
+	}
+}
+
+// isFree возвращает true, если переход из from в to не сталкивается с уже спланированными агентами, иначе false.
+func (s *Solver) isFree(from, to state) bool {
+	if _, ok := s.occupied[to]; ok {
+		return false
+	}
+
+	if t, ok := s.parked[to.coords]; ok && t <= to.t {
+		return false
+	}
+
+	_, swapped := s.moves[move{from: to.coords, to: from.coords, t: from.t}]
+
+	return !swapped
+}
+
+// canPark возвращает true, если агент может навсегда остаться в клетке current, иначе false.
+func (s *Solver) canPark(current state) bool {
+	t, ok := s.latest[current.coords]
+
+	return !ok || t < current.t
+}
+
+// reserve занимает клетки и переходы path для агентов с более низким приоритетом.
+func (s *Solver) reserve(path []cells.Coordinates) {
+	for t, coords := range path {
+		s.occupied[state{coords: coords, t: t}] = struct{}{}
+		s.latest[coords] = max(s.latest[coords], t)
+
+		if t > 0 {
+			s.moves[move{from: path[t-1], to: coords, t: t - 1}] = struct{}{}
+		}
+	}
+
+	s.parked[path[len(path)-1]] = len(path) - 1
+}
+
+// validate проверяет, что начала и концы agents лежат в пределах mz, не приходятся на стены и не совпадают.
+func validate(mz maze.Maze, agents []Agent) error {
+	starts := make(map[cells.Coordinates]struct{}, len(agents))
+	ends := make(map[cells.Coordinates]struct{}, len(agents))
+
+	for i, agent := range agents {
+		err := sutils.Validate(mz, agent.Start, agent.End)
+		if err != nil {
+			return fmt.Errorf("agent %d: %w", i, err)
+		}
+
+		_, sharedStart := starts[agent.Start]
+		_, sharedEnd := ends[agent.End]
+
+		if sharedStart || sharedEnd {
+			return fmt.Errorf("agent %d: %w", i, ErrSharedCell)
+		}
+
+		starts[agent.Start] = struct{}{}
+		ends[agent.End] = struct{}{}
+	}
+
+	return nil
+}
+
+// newPlan возвращает Plan, дополняя пути агентов стоянием на концах до общего количества шагов.
+func newPlan(paths [][]cells.Coordinates, explored int, duration time.Duration) Plan {
+	makespan := 0
+	for _, path := range paths {
+		makespan = max(makespan, len(path)-1)
+	}
+
+	for i, path := range paths {
+		for len(path) <= makespan {
+			path = append(path, path[len(path)-1])
+		}
+
+		paths[i] = path
+	}
+
+	return Plan{
+		Paths:    paths,
+		Makespan: makespan,
+		Explored: explored,
+		Duration: duration,
+	}
+}
+
+// restorePath восстанавливает путь от origin до target по predecessors.
+func restorePath(origin, target state, predecessors map[state]state) []cells.Coordinates {
+	path := make([]cells.Coordinates, target.t+1)
+
+	for current := target; ; current = predecessors[current] {
+		path[current.t] = current.coords
+
+		if current == origin {
+			return path
+		}
+	}
+}
+
+// state - состояние агента: клетка и шаг.
+type state struct {
+	coords cells.Coordinates
+	t      int
+}
+
+// move - переход агента из клетки from на шаге t в клетку to на шаге t + 1.
+type move struct {
+	from cells.Coordinates
+	to   cells.Coordinates
+	t    int
+}
+
+// item - запись очереди A*.
+type item struct {
+	state    state
+	priority int // Шаг плюс количество шагов до конца без учёта других агентов.
+}
+
+// states реализует интерфейс heap.Interface, описанный в container/heap; при равных приоритетах
+// первыми достаются более поздние состояния, то есть более близкие к концу.
+type states []item
+
+func (s states) Len() int { return len(s) }
+
+func (s states) Less(i, j int) bool {
+	return s[i].priority < s[j].priority || s[i].priority == s[j].priority && s[i].state.t > s[j].state.t
+}
+
+func (s states) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+
+func (s *states) Push(x any) { *s = append(*s, x.(item)) }
+
+func (s *states) Pop() any {
+	old := *s
+	n := len(old)
+	it := old[n-1]
+	*s = old[:n-1]
+
+	return it
+}
@@ -0,0 +1,159 @@
+package multiagent_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/multiagent"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils/sutilstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiAgentSolverSolveAgents(t *testing.T) {
+	tests := []struct {
+		name             string
+		mz               maze.Maze
+		agents           []multiagent.Agent
+		expectedMakespan int
+		expectedErr      error
+	}{
+		{
+			name: "start is a wall",
+			mz:   maze.New(2, 2),
+			agents: []multiagent.Agent{
+				{Start: cells.Coordinates{X: 0, Y: 0}, End: cells.Coordinates{X: 1, Y: 1}},
+			},
+			expectedErr: sutils.ErrMaskedCell,
+		},
+		{
+			name: "agents share end",
+			mz:   sutilstest.NewGridMaze(3, 3),
+			agents: []multiagent.Agent{
+				{Start: cells.Coordinates{X: 0, Y: 0}, End: cells.Coordinates{X: 2, Y: 2}},
+				{Start: cells.Coordinates{X: 2, Y: 0}, End: cells.Coordinates{X: 2, Y: 2}},
+			},
+			expectedErr: multiagent.ErrSharedCell,
+		},
+		{
+			name: "end is unreachable",
+			mz: func() maze.Maze {
+				mz := newCorridorMaze(4)
+				mz.Cells[cells.Coordinates{X: 3, Y: 1}].Type = cells.Pass
+
+				return mz
+			}(),
+			agents: []multiagent.Agent{
+				{Start: cells.Coordinates{X: 0, Y: 0}, End: cells.Coordinates{X: 3, Y: 1}},
+			},
+			expectedErr: sutils.ErrUnreachable,
+		},
+		{
+			name: "agents can't pass each other in corridor",
+			mz:   newCorridorMaze(4),
+			agents: []multiagent.Agent{
+				{Start: cells.Coordinates{X: 0, Y: 0}, End: cells.Coordinates{X: 3, Y: 0}},
+				{Start: cells.Coordinates{X: 3, Y: 0}, End: cells.Coordinates{X: 0, Y: 0}},
+			},
+			expectedErr: multiagent.ErrNoPlan,
+		},
+		{
+			name: "agent waits in pocket to let the other pass",
+			mz:   newCorridorMaze(5, cells.Coordinates{X: 3, Y: 1}),
+			agents: []multiagent.Agent{
+				{Start: cells.Coordinates{X: 0, Y: 0}, End: cells.Coordinates{X: 4, Y: 0}},
+				{Start: cells.Coordinates{X: 4, Y: 0}, End: cells.Coordinates{X: 0, Y: 0}},
+			},
+			expectedMakespan: 7,
+		},
+		{
+			name: "agents cross open grid",
+			mz:   sutilstest.NewGridMaze(6, 6),
+			agents: []multiagent.Agent{
+				{Start: cells.Coordinates{X: 0, Y: 0}, End: cells.Coordinates{X: 5, Y: 5}},
+				{Start: cells.Coordinates{X: 5, Y: 0}, End: cells.Coordinates{X: 0, Y: 5}},
+				{Start: cells.Coordinates{X: 0, Y: 5}, End: cells.Coordinates{X: 5, Y: 0}},
+				{Start: cells.Coordinates{X: 5, Y: 5}, End: cells.Coordinates{X: 0, Y: 0}},
+				{Start: cells.Coordinates{X: 2, Y: 2}, End: cells.Coordinates{X: 3, Y: 3}},
+			},
+			expectedMakespan: 10,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := multiagent.NewSolver(sutils.DefaultCosts()).SolveAgents(tt.mz, tt.agents)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expectedMakespan, plan.Makespan)
+			assert.True(t, isPlanValid(tt.mz, tt.agents, plan))
+		})
+	}
+}
+
+// isPlanValid проверяет, что агенты идут по переходам или стоят на месте от начал до концов,
+// не оказываются в одной клетке на одном шаге и не меняются клетками за один шаг.
+func isPlanValid(mz maze.Maze, agents []multiagent.Agent, plan multiagent.Plan) bool {
+	for i, path := range plan.Paths {
+		if len(path) != plan.Makespan+1 || path[0] != agents[i].Start || path[plan.Makespan] != agents[i].End {
+			return false
+		}
+
+		for t := 1; t < len(path); t++ {
+			if path[t] != path[t-1] && !slices.Contains(mz.Cells[path[t-1]].Transitions, path[t]) {
+				return false
+			}
+		}
+	}
+
+	for t := range plan.Makespan + 1 {
+		for i := range plan.Paths {
+			for j := i + 1; j < len(plan.Paths); j++ {
+				a, b := plan.Paths[i], plan.Paths[j]
+
+				if a[t] == b[t] || t > 0 && a[t] == b[t-1] && a[t-1] == b[t] {
+					return false
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+// newCorridorMaze возвращает лабиринт высотой 2 с коридором длины length в верхней строке
+// и тупиками pockets в нижней строке, связанными с клетками коридора над ними.
+func newCorridorMaze(length int, pockets ...cells.Coordinates) maze.Maze {
+	mz := maze.New(2, length)
+
+	for x := range length {
+		coords := cells.Coordinates{X: x, Y: 0}
+		mz.Cells[coords].Type = cells.Pass
+
+		if x > 0 {
+			link(mz, coords, cells.Coordinates{X: x - 1, Y: 0})
+		}
+	}
+
+	for _, pocket := range pockets {
+		mz.Cells[pocket].Type = cells.Pass
+		link(mz, pocket, cells.Coordinates{X: pocket.X, Y: 0})
+	}
+
+	return mz
+}
+
+// link связывает клетки a и b переходами в обе стороны.
+func link(mz maze.Maze, a, b cells.Coordinates) {
+	mz.Cells[a].Transitions = append(mz.Cells[a].Transitions, b)
+	mz.Cells[b].Transitions = append(mz.Cells[b].Transitions, a)
+}
@@ -1,24 +1,116 @@
 package solvers
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/astar"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/bfs"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/bidirectional"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/deadend"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/dfs"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/dijkstra"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/dstarlite"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/fogofwar"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/jps"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/kshortest"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/multiagent"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/tremaux"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/turns"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/wallfollower"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/waypoints"
+)
+
+const (
+	defaultK           = 5 // Количество путей, перечисляемых решателем kshortest по умолчанию.
+	defaultSightRadius = 3 // Дальность видимости агента в тумане войны по умолчанию.
+
+	defaultTurnPenalty  sutils.Cost = 2 // Штраф за поворот по умолчанию.
+	defaultUTurnPenalty sutils.Cost = 4 // Штраф за разворот по умолчанию.
 )
 
+// ErrUnknownType возвращается, если модель стоимости ссылается на несуществующий тип клетки.
+var ErrUnknownType = errors.New("unknown cell type")
+
 type solver interface {
-	Solve(mz maze.Maze, begin, end cells.Coordinates) []cells.Coordinates
+	Solve(mz maze.Maze, begin, end cells.Coordinates) (sutils.Result, error)
 }
 
-// New как фабрика возвращает конкретную реализацию Solver по строке, обозначающей желаемую реализацию.
-func New(solverType string) solver {
+// New как фабрика возвращает конкретную реализацию Solver по строке, обозначающей желаемую реализацию,
+// со стоимостью путей в модели costs.
+func New(solverType string, costs sutils.CostModel) solver {
 	switch solverType {
 	case "dijkstra":
-		return dijkstra.NewSolver()
+		return dijkstra.NewSolver(costs)
 	case "mdfs":
-		return dfs.NewSolver()
+		return dfs.NewSolver(costs)
+	case "bfs":
+		return bfs.NewSolver(costs)
+	case "astar", "astar-manhattan":
+		return astar.NewSolver(costs, astar.Manhattan)
+	case "astar-euclidean":
+		return astar.NewSolver(costs, astar.Euclidean)
+	case "astar-zero":
+		return astar.NewSolver(costs, astar.Zero)
+	case "bidirectional":
+		return bidirectional.NewSolver(costs, false)
+	case "bidirectional-astar":
+		return bidirectional.NewSolver(costs, true)
+	case "wallfollower", "wallfollower-left":
+		return wallfollower.NewSolver(costs, wallfollower.Left)
+	case "wallfollower-right":
+		return wallfollower.NewSolver(costs, wallfollower.Right)
+	case "turns":
+		return turns.NewSolver(costs, defaultTurnPenalty, defaultUTurnPenalty)
+	case "tremaux":
+		return tremaux.NewSolver(costs)
+	case "deadend":
+		return deadend.NewSolver(costs)
+	case "dstarlite":
+		return dstarlite.NewSolver(costs)
+	case "jps":
+		return jps.NewSolver(costs)
+	case "fogofwar":
+		return fogofwar.NewSolver(costs, defaultSightRadius)
+	case "kshortest":
+		return kshortest.NewSolver(costs, defaultK)
+	case "multiagent":
+		return multiagent.NewSolver(costs)
+	case "waypoints":
+		return waypoints.NewSolver(costs, false)
+	case "waypoints-ordered":
+		return waypoints.NewSolver(costs, true)
 	default:
-		return dijkstra.NewSolver()
+		return dijkstra.NewSolver(costs)
 	}
 }
+
+// NewCostModel возвращает модель стоимости путей со стоимостями типов клеток, заданными по их названиям,
+// и надбавками edges; типы, стоимости которых не заданы, стоят столько же, сколько в модели по умолчанию.
+func NewCostModel(types map[string]int, chargeStart bool, edges map[sutils.Edge]int) (sutils.CostModel, error) {
+	typeCosts := sutils.DefaultTypes()
+
+	for name, cost := range types {
+		t, ok := cells.Names[name]
+		if !ok {
+			return nil, fmt.Errorf("type %q: %w", name, ErrUnknownType)
+		}
+
+		typeCosts[t] = sutils.Cost(cost)
+	}
+
+	edgeCosts := make(map[sutils.Edge]sutils.Cost, len(edges))
+	for edge, cost := range edges {
+		edgeCosts[edge] = sutils.Cost(cost)
+	}
+
+	costs, err := sutils.NewCosts(typeCosts, chargeStart, edgeCosts)
+	if err != nil {
+		return nil, fmt.Errorf("can`t create cost model: %w", err)
+	}
+
+	return costs, nil
+}
@@ -0,0 +1,76 @@
+package solvers_test
+
+import (
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCostModel(t *testing.T) {
+	mz := maze.New(1, 3)
+	mz.Cells[cells.Coordinates{X: 0, Y: 0}].Type = cells.LightedPass
+	mz.Cells[cells.Coordinates{X: 1, Y: 0}].Type = cells.Pass
+	mz.Cells[cells.Coordinates{X: 2, Y: 0}].Type = cells.LightedPass
+
+	path := []cells.Coordinates{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}}
+
+	tests := []struct {
+		name         string
+		types        map[string]int
+		chargeStart  bool
+		edges        map[sutils.Edge]int
+		expectedCost sutils.Cost
+		expectedErr  error
+	}{
+		{
+			name:         "default types",
+			chargeStart:  true,
+			expectedCost: 4,
+		},
+		{
+			name:         "default types without start cost",
+			expectedCost: 3,
+		},
+		{
+			name:         "default types with edges",
+			chargeStart:  true,
+			edges:        map[sutils.Edge]int{{From: path[1], To: path[2]}: 10},
+			expectedCost: 14,
+		},
+		{
+			name:         "missing types are taken from default model",
+			types:        map[string]int{"Pass": 7},
+			chargeStart:  true,
+			expectedCost: 9,
+		},
+		{
+			name:        "unknown type",
+			types:       map[string]int{"Lava": 7},
+			expectedErr: solvers.ErrUnknownType,
+		},
+		{
+			name:        "non-positive type cost",
+			types:       map[string]int{"Pass": 0},
+			expectedErr: sutils.ErrInvalidCost,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			costs, err := solvers.NewCostModel(tt.types, tt.chargeStart, tt.edges)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedCost, sutils.PathCost(costs, mz, path))
+		})
+	}
+}
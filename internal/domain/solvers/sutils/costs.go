@@ -0,0 +1,116 @@
+package sutils
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+)
+
+// ErrInvalidCost возвращается, если проходимому типу клетки не задана положительная стоимость
+// или надбавка за переход отрицательна.
+var ErrInvalidCost = errors.New("cost must be positive for passable types and non-negative for edges")
+
+// Cost - стоимость пути или его части; в отличие от cells.Type она не обозначает тип клетки.
+type Cost int
+
+// CostModel определяет стоимость путей независимо от числовых значений типов клеток.
+type CostModel interface {
+	Start(mz maze.Maze, start cells.Coordinates) Cost   // Стоимость входа в начальную клетку пути.
+	Step(mz maze.Maze, from, to cells.Coordinates) Cost // Стоимость перехода из from в соседнюю клетку to.
+	MinStep() Cost                                      // Нижняя граница стоимости перехода, масштабирующая эвристики.
+}
+
+// Edge - переход из клетки From в соседнюю клетку To.
+type Edge struct {
+	From cells.Coordinates
+	To   cells.Coordinates
+}
+
+// Costs - CostModel, в которой переход стоит входа в клетку по её типу плюс надбавку за сам переход.
+type Costs struct {
+	types       map[cells.Type]Cost // Стоимость входа в клетку каждого проходимого типа.
+	chargeStart bool                // Если true, вход в начальную клетку оплачивается по её типу.
+	edges       map[Edge]Cost       // Надбавки за отдельные переходы.
+	minStep     Cost
+}
+
+// NewCosts возвращает указатель на инициализированный Costs со стоимостями типов types и надбавками edges;
+// при chargeStart = true стоимость пути включает стоимость начальной клетки.
+func NewCosts(types map[cells.Type]Cost, chargeStart bool, edges map[Edge]Cost) (*Costs, error) {
+	minStep := Cost(math.MaxInt)
+
+	for _, t := range cells.Types {
+		if t == cells.Wall {
+			continue
+		}
+
+		if types[t] <= 0 {
+			return nil, fmt.Errorf("type %d: %w", t, ErrInvalidCost)
+		}
+
+		minStep = min(minStep, types[t])
+	}
+
+	for edge, cost := range edges {
+		if cost < 0 {
+			return nil, fmt.Errorf("edge %d:%d-%d:%d: %w", edge.From.X, edge.From.Y, edge.To.X, edge.To.Y, ErrInvalidCost)
+		}
+	}
+
+	return &Costs{
+		types:       types,
+		chargeStart: chargeStart,
+		edges:       edges,
+		minStep:     minStep,
+	}, nil
+}
+
+// DefaultTypes возвращает стоимости типов клеток по умолчанию: освещённый проход стоит 1, обычный - 2.
+func DefaultTypes() map[cells.Type]Cost {
+	return map[cells.Type]Cost{cells.LightedPass: 1, cells.Pass: 2}
+}
+
+// DefaultCosts возвращает указатель на Costs со стоимостями типов DefaultTypes,
+// в которой начальная клетка входит в стоимость пути, а надбавок за переходы нет.
+func DefaultCosts() *Costs {
+	costs, _ := NewCosts(DefaultTypes(), true, nil) // Стоимости корректны.
+
+	return costs
+}
+
+// Start возвращает стоимость входа в начальную клетку start.
+func (c *Costs) Start(mz maze.Maze, start cells.Coordinates) Cost {
+	if !c.chargeStart {
+		return 0
+	}
+
+	return c.types[mz.Cells[start].Type]
+}
+
+// Step возвращает стоимость перехода из from в соседнюю клетку to.
+func (c *Costs) Step(mz maze.Maze, from, to cells.Coordinates) Cost {
+	return c.types[mz.Cells[to].Type] + c.edges[Edge{From: from, To: to}]
+}
+
+// MinStep возвращает наименьшую стоимость входа в проходимую клетку; надбавки неотрицательны и её не уменьшают.
+func (c *Costs) MinStep() Cost {
+	return c.minStep
+}
+
+// PathCost возвращает стоимость пути в модели costs.
+func PathCost(costs CostModel, mz maze.Maze, path []cells.Coordinates) Cost {
+	if len(path) == 0 {
+		return 0
+	}
+
+	cost := costs.Start(mz, path[0])
+
+	for i := 1; i < len(path); i++ {
+		cost += costs.Step(mz, path[i-1], path[i])
+	}
+
+	return cost
+}
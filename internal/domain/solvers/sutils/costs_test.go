@@ -0,0 +1,80 @@
+package sutils_test
+
+import (
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCosts(t *testing.T) {
+	edge := sutils.Edge{From: cells.Coordinates{X: 0, Y: 0}, To: cells.Coordinates{X: 1, Y: 0}}
+
+	tests := []struct {
+		name        string
+		types       map[cells.Type]sutils.Cost
+		edges       map[sutils.Edge]sutils.Cost
+		expectedErr error
+	}{
+		{
+			name:        "passable type has no cost",
+			types:       map[cells.Type]sutils.Cost{cells.LightedPass: 1},
+			expectedErr: sutils.ErrInvalidCost,
+		},
+		{
+			name:        "passable type is free",
+			types:       map[cells.Type]sutils.Cost{cells.LightedPass: 1, cells.Pass: 0},
+			expectedErr: sutils.ErrInvalidCost,
+		},
+		{
+			name:        "negative edge toll",
+			types:       map[cells.Type]sutils.Cost{cells.LightedPass: 1, cells.Pass: 2},
+			edges:       map[sutils.Edge]sutils.Cost{edge: -1},
+			expectedErr: sutils.ErrInvalidCost,
+		},
+		{
+			name:  "valid model",
+			types: map[cells.Type]sutils.Cost{cells.LightedPass: 3, cells.Pass: 2},
+			edges: map[sutils.Edge]sutils.Cost{edge: 4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			costs, err := sutils.NewCosts(tt.types, true, tt.edges)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, sutils.Cost(2), costs.MinStep())
+		})
+	}
+}
+
+func TestPathCost(t *testing.T) {
+	mz := maze.New(1, 3)
+	mz.Cells[cells.Coordinates{X: 0, Y: 0}].Type = cells.Pass
+	mz.Cells[cells.Coordinates{X: 1, Y: 0}].Type = cells.LightedPass
+	mz.Cells[cells.Coordinates{X: 2, Y: 0}].Type = cells.Pass
+
+	path := []cells.Coordinates{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}}
+	toll := map[sutils.Edge]sutils.Cost{{From: path[1], To: path[2]}: 10}
+	types := map[cells.Type]sutils.Cost{cells.LightedPass: 5, cells.Pass: 1}
+
+	withStart, err := sutils.NewCosts(types, true, toll)
+	require.NoError(t, err)
+
+	withoutStart, err := sutils.NewCosts(types, false, toll)
+	require.NoError(t, err)
+
+	assert.Equal(t, sutils.Cost(5), sutils.PathCost(sutils.DefaultCosts(), mz, path))
+	assert.Equal(t, sutils.Cost(17), sutils.PathCost(withStart, mz, path))
+	assert.Equal(t, sutils.Cost(16), sutils.PathCost(withoutStart, mz, path))
+	assert.Equal(t, sutils.Cost(0), sutils.PathCost(withStart, mz, nil))
+}
@@ -11,10 +11,10 @@ type Heap struct {
 	heap innerHeap // Heap - обёртка innerHeap, абстрагируюющая от понимания применения container/heap
 }
 
-// Item содержит координаты клетки и её тип.
+// Item содержит координаты клетки и её вес.
 type Item struct {
 	Vertex cells.Coordinates
-	Weight cells.Type
+	Weight Cost
 }
 
 // New возвращает инициализированный Heap.
@@ -38,6 +38,11 @@ func (h *Heap) Push(item Item) {
 	heap.Push(&h.heap, &item)
 }
 
+// Peek возвращает Item с наименьшим Item.Weight в куче, не удаляя из неё.
+func (h *Heap) Peek() *Item {
+	return h.heap[0]
+}
+
 // Pop возвращает Item с наименьшим Item.Weight в куче, удаляя из неё.
 func (h *Heap) Pop() *Item {
 	return heap.Pop(&h.heap).(*Item)
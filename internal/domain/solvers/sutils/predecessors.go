@@ -1,6 +1,11 @@
 package sutils
 
-import "github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+import (
+	"math/big"
+	"slices"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+)
 
 const (
 	MissingX = -1
@@ -23,3 +28,70 @@ func NewPredecessors(height, width int) Predecessors {
 
 	return predecessors
 }
+
+// PredecessorSets - словарь {координаты - все координаты, через которые к ним ведут кратчайшие пути};
+// в отличие от Predecessors описывает не дерево, а ациклический граф всех кратчайших путей.
+type PredecessorSets map[cells.Coordinates][]cells.Coordinates
+
+// CountPaths возвращает количество различных путей от start до end по ps.
+func CountPaths(start, end cells.Coordinates, ps PredecessorSets) *big.Int {
+	counts := make(map[cells.Coordinates]*big.Int)
+
+	var count func(current cells.Coordinates) *big.Int
+
+	count = func(current cells.Coordinates) *big.Int {
+		if current == start {
+			return big.NewInt(1)
+		}
+
+		if c, ok := counts[current]; ok {
+			return c
+		}
+
+		c := new(big.Int)
+		for _, previous := range ps[current] {
+			c.Add(c, count(previous))
+		}
+
+		counts[current] = c
+
+		return c
+	}
+
+	return count(end)
+}
+
+// RestorePaths восстанавливает не более limit различных путей от start до end по ps.
+func RestorePaths(start, end cells.Coordinates, ps PredecessorSets, limit int) [][]cells.Coordinates {
+	var (
+		paths        [][]cells.Coordinates
+		invertedPath []cells.Coordinates
+	)
+
+	var restore func(current cells.Coordinates)
+
+	restore = func(current cells.Coordinates) {
+		if len(paths) == limit {
+			return
+		}
+
+		invertedPath = append(invertedPath, current)
+		defer func() { invertedPath = invertedPath[:len(invertedPath)-1] }()
+
+		if current == start {
+			path := slices.Clone(invertedPath)
+			slices.Reverse(path)
+			paths = append(paths, path)
+
+			return
+		}
+
+		for _, previous := range ps[current] {
+			restore(previous)
+		}
+	}
+
+	restore(end)
+
+	return paths
+}
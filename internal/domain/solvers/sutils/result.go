@@ -0,0 +1,62 @@
+package sutils
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+)
+
+var (
+	// ErrUnreachable возвращается, если конец недостижим из начала.
+	ErrUnreachable = errors.New("end is unreachable from start")
+	// ErrOutOfBounds возвращается, если начало или конец лежат за пределами лабиринта.
+	ErrOutOfBounds = errors.New("coordinates are out of maze bounds")
+	// ErrMaskedCell возвращается, если начало или конец приходятся на стену.
+	ErrMaskedCell = errors.New("coordinates point to masked cell")
+)
+
+// Result содержит результат поиска пути.
+type Result struct {
+	Path     []cells.Coordinates // Путь от начала до конца включительно.
+	Cost     Cost                // Стоимость пути в модели стоимости решателя.
+	Explored int                 // Количество клеток, исследованных решателем.
+	Duration time.Duration       // Время, затраченное на поиск.
+}
+
+// Validate проверяет, что start и end лежат в пределах mz и не приходятся на стены.
+func Validate(mz maze.Maze, start, end cells.Coordinates) error {
+	for _, coords := range []cells.Coordinates{start, end} {
+		cell, ok := mz.Cells[coords]
+		if !ok {
+			return fmt.Errorf("cell %d:%d: %w", coords.X, coords.Y, ErrOutOfBounds)
+		}
+
+		if cell.Type == cells.Wall {
+			return fmt.Errorf("cell %d:%d: %w", coords.X, coords.Y, ErrMaskedCell)
+		}
+	}
+
+	return nil
+}
+
+// NewResult возвращает Result для найденного в mz пути со стоимостью в модели costs;
+// если путь пуст, возвращается также ErrUnreachable.
+func NewResult(costs CostModel, mz maze.Maze, path []cells.Coordinates, explored int, duration time.Duration) (
+	Result, error,
+) {
+	result := Result{
+		Path:     path,
+		Cost:     PathCost(costs, mz, path),
+		Explored: explored,
+		Duration: duration,
+	}
+
+	if len(path) == 0 {
+		return result, ErrUnreachable
+	}
+
+	return result, nil
+}
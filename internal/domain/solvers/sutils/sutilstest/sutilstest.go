@@ -0,0 +1,117 @@
+package sutilstest
+
+import (
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/gutils"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/prim"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+	"github.com/stretchr/testify/require"
+)
+
+// NewGridMaze возвращает лабиринт из обычных проходов, в котором связаны все соседние проходы,
+// а клетки walls остаются стенами.
+func NewGridMaze(height, width int, walls ...cells.Coordinates) maze.Maze {
+	mz := NewUnlinkedMaze(height, width)
+
+	for _, coords := range walls {
+		mz.Cells[coords].Type = cells.Wall
+	}
+
+	for coords, cell := range mz.Cells {
+		if cell.Type == cells.Wall {
+			continue
+		}
+
+		for _, next := range []cells.Coordinates{{X: coords.X + 1, Y: coords.Y}, {X: coords.X, Y: coords.Y + 1}} {
+			if nextCell, ok := mz.Cells[next]; ok && nextCell.Type != cells.Wall {
+				cell.Transitions = append(cell.Transitions, next)
+				nextCell.Transitions = append(nextCell.Transitions, coords)
+			}
+		}
+	}
+
+	return mz
+}
+
+// NewUnlinkedMaze возвращает лабиринт из проходов без единого перехода.
+func NewUnlinkedMaze(height, width int) maze.Maze {
+	mz := maze.New(height, width)
+
+	for _, cell := range mz.Cells {
+		cell.Type = cells.Pass
+	}
+
+	return mz
+}
+
+// NewBraidedMaze возвращает лабиринт с циклами: идеальный лабиринт, каждый тупик которого
+// соединён с ещё одной смежной клеткой.
+func NewBraidedMaze(t *testing.T, height, width int) maze.Maze {
+	t.Helper()
+
+	mz, err := prim.NewGenerator().Generate(height, width)
+	require.NoError(t, err)
+
+	for coords, cell := range mz.Cells {
+		if len(cell.Transitions) != 1 {
+			continue
+		}
+
+		for {
+			next, err := gutils.GetRandomAdjacentCoords(coords, height, width)
+			require.NoError(t, err)
+
+			if next != cell.Transitions[0] {
+				cell.Transitions = append(cell.Transitions, next)
+				mz.Cells[next].Transitions = append(mz.Cells[next].Transitions, coords)
+
+				break
+			}
+		}
+	}
+
+	return mz
+}
+
+// IsPathValid проверяет, что путь ведёт от start до end по переходам лабиринта, не повторяя клеток.
+func IsPathValid(mz maze.Maze, path []cells.Coordinates, start, end cells.Coordinates) bool {
+	if len(path) == 0 || path[0] != start || path[len(path)-1] != end {
+		return false
+	}
+
+	return IsPathLinked(mz, path)
+}
+
+// IsPathLinked проверяет, что путь не идёт сквозь стены, не повторяет клеток
+// и между его последовательными клетками есть переходы. Пустой путь считается связным.
+func IsPathLinked(mz maze.Maze, path []cells.Coordinates) bool {
+	seen := make(map[cells.Coordinates]struct{}, len(path))
+
+	for i, coords := range path {
+		if _, ok := seen[coords]; ok || mz.Cells[coords].Type == cells.Wall {
+			return false
+		}
+
+		seen[coords] = struct{}{}
+
+		if i == 0 {
+			continue
+		}
+
+		linked := false
+
+		for _, next := range mz.Cells[path[i-1]].Transitions {
+			if next == coords {
+				linked = true
+			}
+		}
+
+		if !linked {
+			return false
+		}
+	}
+
+	return true
+}
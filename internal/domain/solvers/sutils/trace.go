@@ -0,0 +1,51 @@
+package sutils
+
+import (
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+)
+
+// EventKind - вид события трассировки поиска.
+type EventKind int
+
+const (
+	Pushed         EventKind = iota // Вершина добавлена в очередь рассмотрения (кучу, стек вызовов).
+	Popped                          // Вершина извлечена из очереди и раскрыта.
+	PredecessorSet                  // Вершине записан предшественник.
+	PathFinalized                   // Вершина вошла в итоговый путь.
+)
+
+// Event описывает одно событие трассировки поиска.
+type Event struct {
+	Kind        EventKind
+	Vertex      cells.Coordinates
+	Predecessor cells.Coordinates // Заполняется только для PredecessorSet.
+}
+
+// Tracer получает события поиска в порядке их возникновения.
+type Tracer interface {
+	Trace(event Event)
+}
+
+// Recorder - Tracer, запоминающий все полученные события.
+type Recorder struct {
+	Events []Event
+}
+
+// Trace добавляет событие в конец Events.
+func (r *Recorder) Trace(event Event) {
+	r.Events = append(r.Events, event)
+}
+
+// Trace передаёт событие tracer, если он задан.
+func Trace(tracer Tracer, event Event) {
+	if tracer != nil {
+		tracer.Trace(event)
+	}
+}
+
+// TracePath передаёт tracer по событию PathFinalized для каждой клетки пути, если он задан.
+func TracePath(tracer Tracer, path []cells.Coordinates) {
+	for _, coords := range path {
+		Trace(tracer, Event{Kind: PathFinalized, Vertex: coords})
+	}
+}
@@ -0,0 +1,28 @@
+package sutils
+
+import (
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+)
+
+// EraseLoops возвращает траекторию, из которой удалены все петли.
+func EraseLoops(trajectory []cells.Coordinates) []cells.Coordinates {
+	path := make([]cells.Coordinates, 0, len(trajectory))
+	positions := make(map[cells.Coordinates]int) // Словарь клетка - её позиция в path.
+
+	for _, coords := range trajectory {
+		if position, ok := positions[coords]; ok { // Агент вернулся в клетку - петля удаляется.
+			for _, erased := range path[position+1:] {
+				delete(positions, erased)
+			}
+
+			path = path[:position+1]
+
+			continue
+		}
+
+		positions[coords] = len(path)
+		path = append(path, coords)
+	}
+
+	return path
+}
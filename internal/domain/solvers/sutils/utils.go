@@ -12,7 +12,7 @@ func RestorePath(start, end cells.Coordinates, ps Predecessors) []cells.Coordina
 	)
 
 	current := end
-	returned := false
+	returned := start == end // Путь до самого себя состоит из одной клетки.
 
 	for current != ps[start] {
 		invertedPath = append(invertedPath, current) // Проходясь по predecessors, получается путь в обратном порядке.
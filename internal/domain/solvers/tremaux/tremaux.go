@@ -0,0 +1,162 @@
+package tremaux
+
+import (
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils"
+)
+
+// Passage - неупорядоченная пара смежных клеток, между которыми есть переход.
+type Passage struct {
+	From cells.Coordinates
+	To   cells.Coordinates
+}
+
+// NewPassage возвращает Passage между a и b, не зависящий от порядка аргументов.
+func NewPassage(a, b cells.Coordinates) Passage {
+	if b.Y < a.Y || (b.Y == a.Y && b.X < a.X) {
+		a, b = b, a
+	}
+
+	return Passage{From: a, To: b}
+}
+
+// Walk содержит результат прогулки агента.
+type Walk struct {
+	Trajectory []cells.Coordinates // Все пройденные агентом клетки по порядку, включая возвраты.
+	Path       []cells.Coordinates // Путь от начала до конца по проходам, помеченным единожды; пуст, если конец не достигнут.
+	Marks      map[Passage]int     // Количество меток (1 или 2) на каждом пройденном проходе.
+}
+
+// Solver - структура решателя по алгоритму Тремо.
+type Solver struct {
+	costs sutils.CostModel // Модель стоимости путей.
+	marks map[Passage]int  // Количество меток на проходах.
+}
+
+// NewSolver возвращает указатель на инициализированный Solver со стоимостью путей в модели costs.
+func NewSolver(costs sutils.CostModel) *Solver {
+	return &Solver{
+		costs: costs,
+		marks: make(map[Passage]int),
+	}
+}
+
+// Solve находит путь от start до end в mz и возвращает его вместе со статистикой поиска.
+func (s *Solver) Solve(mz maze.Maze, start, end cells.Coordinates) (sutils.Result, error) {
+	result, _, err := s.SolveWalk(mz, start, end)
+
+	return result, err
+}
+
+// SolveWalk работает как Solve, дополнительно возвращая прогулку агента, по которой найден путь.
+func (s *Solver) SolveWalk(mz maze.Maze, start, end cells.Coordinates) (sutils.Result, Walk, error) {
+	err := sutils.Validate(mz, start, end)
+	if err != nil {
+		return sutils.Result{}, Walk{}, err
+	}
+
+	begin := time.Now()
+
+	walk := s.Walk(mz, start, end)
+
+	explored := make(map[cells.Coordinates]struct{}, len(walk.Trajectory))
+	for _, coords := range walk.Trajectory {
+		explored[coords] = struct{}{}
+	}
+
+	result, err := sutils.NewResult(s.costs, mz, walk.Path, len(explored), time.Since(begin))
+
+	return result, walk, err
+}
+
+// Walk проводит агента от start до end по алгоритму Тремо и возвращает его траекторию, путь и метки.
+func (s *Solver) Walk(mz maze.Maze, start, end cells.Coordinates) Walk {
+	// Суть алгоритма Тремо:
+	//
+	// Агент знает лишь переходы текущей клетки и метки на них; проходя по проходу, он ставит на нём метку.
+	//
+	// Правила:
+	// 1) Если агент пришёл в уже посещённую клетку (на других её проходах есть метки) по проходу
+	//    с одной меткой, он разворачивается и возвращается по нему.
+	// 2) Иначе он выбирает проход с наименьшим количеством меток, никогда не выбирая проходы с двумя метками.
+	// 3) Если таких проходов нет, конец недостижим.
+	//
+	// В отличие от правила руки, алгоритм работает и в лабиринтах с циклами. По достижении конца проходы,
+	// помеченные единожды, образуют путь от начала до конца.
+	s.marks = make(map[Passage]int) // Метки возвращаются пользователю, поэтому словарь не переиспользуется.
+
+	previous, current := start, start
+	trajectory := []cells.Coordinates{start}
+
+	for current != end {
+		next, ok := s.choose(mz.Cells[current], previous, current)
+		if !ok {
+			return Walk{Trajectory: trajectory, Path: []cells.Coordinates{}, Marks: s.marks}
+		}
+
+		s.marks[NewPassage(current, next)]++
+
+		previous, current = current, next
+		trajectory = append(trajectory, current)
+	}
+
+	return Walk{Trajectory: trajectory, Path: s.restorePath(mz, start, end), Marks: s.marks}
+}
+
+// choose выбирает следующую клетку по правилам Тремо, возвращая false, если идти некуда.
+func (s *Solver) choose(cell *cells.Cell, previous, current cells.Coordinates) (cells.Coordinates, bool) {
+	if previous != current && s.marks[NewPassage(previous, current)] == 1 && s.isVisited(cell, previous, current) {
+		return previous, true // Правило 1: разворачиваемся.
+	}
+
+	best, bestMarks := cells.Coordinates{}, 2
+
+	for _, next := range cell.Transitions { // Правило 2: проход с наименьшим количеством меток.
+		if marks := s.marks[NewPassage(current, next)]; marks < bestMarks {
+			best, bestMarks = next, marks
+		}
+	}
+
+	return best, bestMarks < 2
+}
+
+// isVisited возвращает true, если на проходах клетки, кроме ведущего в previous, есть метки.
+func (s *Solver) isVisited(cell *cells.Cell, previous, current cells.Coordinates) bool {
+	for _, next := range cell.Transitions {
+		if next != previous && s.marks[NewPassage(current, next)] > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// restorePath восстанавливает путь от start до end по проходам, помеченным единожды.
+func (s *Solver) restorePath(mz maze.Maze, start, end cells.Coordinates) []cells.Coordinates {
+	path := []cells.Coordinates{start}
+	previous, current := start, start
+
+	for current != end {
+		found := false
+
+		for _, next := range mz.Cells[current].Transitions {
+			if next != previous && s.marks[NewPassage(current, next)] == 1 {
+				previous, current = current, next
+				found = true
+
+				break
+			}
+		}
+
+		if !found { // Недостижимо при соблюдении правил, но защищает от бесконечного цикла.
+			return []cells.Coordinates{}
+		}
+
+		path = append(path, current)
+	}
+
+	return path
+}
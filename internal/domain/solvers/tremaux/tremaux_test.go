@@ -0,0 +1,87 @@
+package tremaux_test
+
+import (
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/cave"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/prim"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/bfs"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils/sutilstest"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/tremaux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTremauxSolverWalkOnPerfectMaze(t *testing.T) {
+	mz, err := prim.NewGenerator().Generate(24, 24)
+	require.NoError(t, err)
+
+	start, end := cells.Coordinates{X: 0, Y: 0}, cells.Coordinates{X: 23, Y: 23}
+
+	walk := tremaux.NewSolver(sutils.DefaultCosts()).Walk(mz, start, end)
+
+	expected, err := bfs.NewSolver(sutils.DefaultCosts()).Solve(mz, start, end)
+	require.NoError(t, err)
+
+	// В идеальном лабиринте путь без петель единственен и совпадает с найденным поиском в ширину.
+	assert.Equal(t, expected.Path, walk.Path)
+	assert.Equal(t, end, walk.Trajectory[len(walk.Trajectory)-1])
+	assert.True(t, areMarksValid(walk.Marks))
+}
+
+func TestTremauxSolverWalkOnCave(t *testing.T) {
+	mz, err := cave.NewGenerator().Generate(32, 32)
+	require.NoError(t, err)
+
+	var passages []cells.Coordinates
+
+	for coords, cell := range mz.Cells {
+		if cell.Type != cells.Wall {
+			passages = append(passages, coords)
+		}
+	}
+
+	s := tremaux.NewSolver(sutils.DefaultCosts())
+
+	for i := 0; i+1 < len(passages) && i < 40; i += 2 {
+		start, end := passages[i], passages[i+1]
+
+		walk := s.Walk(mz, start, end)
+
+		assert.True(t, sutilstest.IsPathValid(mz, walk.Path, start, end))
+		assert.True(t, areMarksValid(walk.Marks))
+	}
+}
+
+func TestTremauxSolverWalkUnreachable(t *testing.T) {
+	mz := maze.New(1, 3)
+	mz.Cells[cells.Coordinates{X: 0, Y: 0}] = &cells.Cell{
+		Type:        cells.Pass,
+		Transitions: []cells.Coordinates{{X: 1, Y: 0}},
+	}
+	mz.Cells[cells.Coordinates{X: 1, Y: 0}] = &cells.Cell{
+		Type:        cells.Pass,
+		Transitions: []cells.Coordinates{{X: 0, Y: 0}},
+	}
+
+	walk := tremaux.NewSolver(sutils.DefaultCosts()).Walk(mz, cells.Coordinates{X: 0, Y: 0}, cells.Coordinates{X: 2, Y: 0})
+
+	assert.Empty(t, walk.Path)
+	assert.Equal(t, map[tremaux.Passage]int{
+		tremaux.NewPassage(cells.Coordinates{X: 0, Y: 0}, cells.Coordinates{X: 1, Y: 0}): 2,
+	}, walk.Marks)
+}
+
+// areMarksValid проверяет, что каждый проход помечен один или два раза.
+func areMarksValid(marks map[tremaux.Passage]int) bool {
+	for _, m := range marks {
+		if m != 1 && m != 2 {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,216 @@
+package turns
+
+import (
+	"container/heap"
+	"slices"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/gutils"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils"
+)
+
+// Heading - направление движения; значение совпадает с индексом сдвига в gutils.Dx и gutils.Dy.
+type Heading int
+
+// Константы направлений. Противоположные направления в сумме дают 3.
+const (
+	Any   Heading = iota - 1 // Направление не задано: первый шаг делается без штрафа.
+	West                     // Запад (влево).
+	North                    // Север (вверх).
+	South                    // Юг (вниз).
+	East                     // Восток (вправо).
+)
+
+// Leg - отрезок маршрута, пройденный по прямой.
+type Leg struct {
+	Heading Heading // Направление движения на отрезке.
+	Length  int     // Количество шагов на отрезке.
+}
+
+// Solver - структура решателя, ищущего самый дешёвый путь с учётом штрафов за повороты и развороты.
+type Solver struct {
+	costs        sutils.CostModel      // Модель стоимости путей.
+	turnPenalty  sutils.Cost           // Штраф за поворот на 90 градусов.
+	uTurnPenalty sutils.Cost           // Штраф за разворот на 180 градусов.
+	dist         map[state]sutils.Cost // Хранит для каждого достигнутого состояния лучшую известную оценку пути.
+	predecessors map[state]state       // Хранит для каждого состояния предыдущее состояние на лучшем пути.
+	explored     int                   // Количество состояний, извлечённых из кучи с актуальной оценкой.
+}
+
+// NewSolver возвращает указатель на инициализированный Solver со стоимостью путей в модели costs,
+// штрафующий каждый поворот на turnPenalty, а каждый разворот - на uTurnPenalty.
+func NewSolver(costs sutils.CostModel, turnPenalty, uTurnPenalty sutils.Cost) *Solver {
+	return &Solver{
+		costs:        costs,
+		turnPenalty:  turnPenalty,
+		uTurnPenalty: uTurnPenalty,
+		dist:         make(map[state]sutils.Cost),
+		predecessors: make(map[state]state),
+	}
+}
+
+// Solve находит путь от start до end в mz, начиная движение в любом направлении, и возвращает его
+// вместе со статистикой поиска. Штрафы влияют на выбор пути, но не входят в Cost результата.
+func (s *Solver) Solve(mz maze.Maze, start, end cells.Coordinates) (sutils.Result, error) {
+	return s.SolveHeading(mz, start, end, Any)
+}
+
+// SolveHeading работает как Solve для агента, который в start смотрит в направлении heading.
+func (s *Solver) SolveHeading(mz maze.Maze, start, end cells.Coordinates, heading Heading) (sutils.Result, error) {
+	err := sutils.Validate(mz, start, end)
+	if err != nil {
+		return sutils.Result{}, err
+	}
+
+	begin := time.Now()
+
+	s.prepare()
+
+	path := s.search(mz, state{coords: start, heading: heading}, end)
+
+	return sutils.NewResult(s.costs, mz, path, s.explored, time.Since(begin))
+}
+
+// search находит алгоритмом Дейкстры самый дешёвый с учётом штрафов путь из origin до end.
+func (s *Solver) search(mz maze.Maze, origin state, end cells.Coordinates) []cells.Coordinates {
+	// Суть поиска с учётом направления:
+	//
+	// Вершина графа - пара (клетка, направление, в котором агент в неё вошёл). Переход в соседнюю клетку
+	// стоит как в модели стоимости плюс штраф, если направление шага отличается от текущего.
+	// Штраф зависит только от пары направлений, поэтому в этом графе работает обычный алгоритм Дейкстры;
+	// путь заканчивается, как только из кучи извлекается любое состояние в end.
+	open := &states{}
+
+	s.dist[origin] = s.costs.Start(mz, origin.coords)
+	heap.Push(open, item{state: origin, weight: s.dist[origin]})
+
+	for open.Len() != 0 {
+		current := heap.Pop(open).(item)
+
+		if current.weight > s.dist[current.state] { // Запись устарела.
+			continue
+		}
+
+		s.explored++
+
+		if current.state.coords == end {
+			return s.restorePath(origin, current.state)
+		}
+
+		for _, next := range mz.Cells[current.state.coords].Transitions {
+			successor := state{coords: next, heading: headingOf(current.state.coords, next)}
+			newDist := current.weight + s.costs.Step(mz, current.state.coords, next) +
+				s.penalty(current.state.heading, successor.heading)
+
+			if oldDist, ok := s.dist[successor]; !ok || newDist < oldDist {
+				s.dist[successor] = newDist
+				s.predecessors[successor] = current.state
+				heap.Push(open, item{state: successor, weight: newDist})
+			}
+		}
+	}
+
+	return []cells.Coordinates{}
+}
+
+// penalty возвращает штраф за смену направления from на to.
+func (s *Solver) penalty(from, to Heading) sutils.Cost {
+	switch {
+	case from == Any || from == to:
+		return 0
+	case from+to == 3: // Противоположные направления.
+		return s.uTurnPenalty
+	default:
+		return s.turnPenalty
+	}
+}
+
+// restorePath восстанавливает путь от origin до target по предшественникам.
+func (s *Solver) restorePath(origin, target state) []cells.Coordinates {
+	var path []cells.Coordinates
+
+	for current := target; current != origin; current = s.predecessors[current] {
+		path = append(path, current.coords)
+	}
+
+	path = append(path, origin.coords)
+	slices.Reverse(path)
+
+	return path
+}
+
+// prepare подготавливает Solver для исполнения SolveHeading.
+func (s *Solver) prepare() {
+	clear(s.dist)
+	clear(s.predecessors)
+
+	s.explored = 0
+}
+
+// Legs разбивает path на отрезки, пройденные по прямой, - пошаговое описание маршрута.
+func Legs(path []cells.Coordinates) []Leg {
+	var legs []Leg
+
+	for i := 1; i < len(path); i++ {
+		heading := headingOf(path[i-1], path[i])
+
+		if len(legs) != 0 && legs[len(legs)-1].Heading == heading {
+			legs[len(legs)-1].Length++
+			continue
+		}
+
+		legs = append(legs, Leg{Heading: heading, Length: 1})
+	}
+
+	return legs
+}
+
+// Turns возвращает количество смен направления на path, включая развороты.
+func Turns(path []cells.Coordinates) int {
+	return max(len(Legs(path))-1, 0)
+}
+
+// headingOf возвращает направление шага из from в соседнюю клетку to.
+func headingOf(from, to cells.Coordinates) Heading {
+	for i := range gutils.Dx {
+		if from.X+gutils.Dx[i] == to.X && from.Y+gutils.Dy[i] == to.Y {
+			return Heading(i)
+		}
+	}
+
+	return Any
+}
+
+// state - состояние агента: клетка и направление, в котором он в неё вошёл.
+type state struct {
+	coords  cells.Coordinates
+	heading Heading
+}
+
+// item - запись кучи.
+type item struct {
+	state  state
+	weight sutils.Cost
+}
+
+// states реализует интерфейс heap.Interface, описанный в container/heap.
+type states []item
+
+func (s states) Len() int { return len(s) }
+
+func (s states) Less(i, j int) bool { return s[i].weight < s[j].weight }
+
+func (s states) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+
+func (s *states) Push(x any) { *s = append(*s, x.(item)) }
+
+func (s *states) Pop() any {
+	old := *s
+	n := len(old)
+	it := old[n-1]
+	*s = old[:n-1]
+
+	return it
+}
@@ -0,0 +1,160 @@
+package turns_test
+
+import (
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/cave"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/dijkstra"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils/sutilstest"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/turns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTurnsSolverSolveHeading(t *testing.T) {
+	type args struct {
+		mz      maze.Maze
+		start   cells.Coordinates
+		end     cells.Coordinates
+		heading turns.Heading
+	}
+
+	tests := []struct {
+		name          string
+		args          args
+		turnPenalty   sutils.Cost
+		uTurnPenalty  sutils.Cost
+		expected      []cells.Coordinates
+		expectedTurns int
+		expectedErr   error
+	}{
+		{
+			name: "end is a wall",
+			args: args{
+				mz:      maze.New(2, 2),
+				start:   cells.Coordinates{X: 0, Y: 0},
+				end:     cells.Coordinates{X: 1, Y: 1},
+				heading: turns.Any,
+			},
+			expectedErr: sutils.ErrMaskedCell,
+		},
+		{
+			name: "open grid is crossed with one turn",
+			args: args{
+				mz:      sutilstest.NewGridMaze(5, 5),
+				start:   cells.Coordinates{X: 0, Y: 0},
+				end:     cells.Coordinates{X: 4, Y: 4},
+				heading: turns.Any,
+			},
+			turnPenalty:   1,
+			uTurnPenalty:  1,
+			expectedTurns: 1,
+		},
+		{
+			name: "u-turn without penalty",
+			args: args{
+				mz:      newRingMaze(),
+				start:   cells.Coordinates{X: 1, Y: 0},
+				end:     cells.Coordinates{X: 0, Y: 0},
+				heading: turns.East,
+			},
+			expected:      []cells.Coordinates{{X: 1, Y: 0}, {X: 0, Y: 0}},
+			expectedTurns: 0,
+		},
+		{
+			name: "expensive u-turn is replaced by going round the ring",
+			args: args{
+				mz:      newRingMaze(),
+				start:   cells.Coordinates{X: 1, Y: 0},
+				end:     cells.Coordinates{X: 0, Y: 0},
+				heading: turns.East,
+			},
+			uTurnPenalty: 20,
+			expected: []cells.Coordinates{
+				{X: 1, Y: 0}, {X: 2, Y: 0}, {X: 2, Y: 1}, {X: 1, Y: 1}, {X: 0, Y: 1}, {X: 0, Y: 0},
+			},
+			expectedTurns: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := turns.NewSolver(sutils.DefaultCosts(), tt.turnPenalty, tt.uTurnPenalty)
+
+			result, err := s.SolveHeading(tt.args.mz, tt.args.start, tt.args.end, tt.args.heading)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+
+			if tt.expected != nil {
+				assert.Equal(t, tt.expected, result.Path)
+			}
+
+			assert.Equal(t, tt.expectedTurns, turns.Turns(result.Path))
+		})
+	}
+}
+
+func TestTurnsSolverSolveOnCave(t *testing.T) {
+	mz, err := cave.NewGenerator().Generate(24, 24)
+	require.NoError(t, err)
+
+	var passages []cells.Coordinates
+
+	for coords, cell := range mz.Cells {
+		if cell.Type != cells.Wall {
+			passages = append(passages, coords)
+		}
+	}
+
+	start, end := passages[0], passages[len(passages)-1]
+
+	expected, err := dijkstra.NewSolver(sutils.DefaultCosts()).Solve(mz, start, end)
+	require.NoError(t, err)
+
+	// Без штрафов поиск по состояниям с направлением совпадает по стоимости с алгоритмом Дейкстры.
+	free, err := turns.NewSolver(sutils.DefaultCosts(), 0, 0).Solve(mz, start, end)
+	require.NoError(t, err)
+	assert.Equal(t, expected.Cost, free.Cost)
+
+	// Со штрафами путь не дешевле кратчайшего и содержит не больше поворотов, чем путь без штрафов.
+	penalized, err := turns.NewSolver(sutils.DefaultCosts(), 5, 10).Solve(mz, start, end)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, penalized.Cost, expected.Cost)
+	assert.LessOrEqual(t, turns.Turns(penalized.Path), turns.Turns(free.Path))
+}
+
+func TestLegs(t *testing.T) {
+	path := []cells.Coordinates{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}, {X: 2, Y: 1}, {X: 1, Y: 1}}
+
+	assert.Equal(t, []turns.Leg{
+		{Heading: turns.East, Length: 2},
+		{Heading: turns.South, Length: 1},
+		{Heading: turns.West, Length: 1},
+	}, turns.Legs(path))
+	assert.Equal(t, 2, turns.Turns(path))
+	assert.Equal(t, 0, turns.Turns(path[:1]))
+}
+
+// newRingMaze возвращает кольцо 2x3 из обычных проходов.
+func newRingMaze() maze.Maze {
+	mz := maze.New(2, 3)
+	ring := []cells.Coordinates{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}, {X: 2, Y: 1}, {X: 1, Y: 1}, {X: 0, Y: 1}}
+
+	for i, coords := range ring {
+		next := ring[(i+1)%len(ring)]
+
+		mz.Cells[coords].Type = cells.Pass
+		mz.Cells[coords].Transitions = append(mz.Cells[coords].Transitions, next)
+		mz.Cells[next].Transitions = append(mz.Cells[next].Transitions, coords)
+	}
+
+	return mz
+}
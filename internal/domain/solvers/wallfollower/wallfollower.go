@@ -0,0 +1,134 @@
+package wallfollower
+
+import (
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils"
+)
+
+// Hand обозначает руку, которой агент держится за стену.
+type Hand int
+
+// Константы рук.
+const (
+	Left  Hand = iota // Левая рука.
+	Right             // Правая рука.
+)
+
+var (
+	headingDx = []int{0, 1, 0, -1} // Сдвиги по x для направлений север, восток, юг, запад (по часовой стрелке).
+	headingDy = []int{-1, 0, 1, 0} // Сдвиги по y для направлений север, восток, юг, запад (по часовой стрелке).
+)
+
+// Walk содержит результат прогулки агента.
+type Walk struct {
+	Trajectory []cells.Coordinates // Все пройденные агентом клетки по порядку, включая возвраты.
+	Path       []cells.Coordinates // Путь от начала до конца с удалёнными петлями; пуст, если конец не достигнут.
+}
+
+// state описывает положение агента: клетку и направление взгляда.
+type state struct {
+	coords  cells.Coordinates
+	heading int
+}
+
+// Solver - структура решателя, моделирующего агента, который идёт вдоль стены.
+type Solver struct {
+	costs sutils.CostModel // Модель стоимости путей.
+	hand  Hand
+	seen  map[state]struct{} // Множество уже встречавшихся положений агента, позволяющее обнаружить зацикливание.
+}
+
+// NewSolver возвращает указатель на инициализированный Solver, держащийся за стену рукой hand,
+// со стоимостью путей в модели costs.
+func NewSolver(costs sutils.CostModel, hand Hand) *Solver {
+	return &Solver{
+		costs: costs,
+		hand:  hand,
+		seen:  make(map[state]struct{}),
+	}
+}
+
+// Solve находит путь от start до end в mz и возвращает его вместе со статистикой поиска.
+func (s *Solver) Solve(mz maze.Maze, start, end cells.Coordinates) (sutils.Result, error) {
+	err := sutils.Validate(mz, start, end)
+	if err != nil {
+		return sutils.Result{}, err
+	}
+
+	begin := time.Now()
+
+	walk := s.Walk(mz, start, end)
+
+	return sutils.NewResult(s.costs, mz, walk.Path, countDistinct(walk.Trajectory), time.Since(begin))
+}
+
+// Walk проводит агента от start до end и возвращает его траекторию и путь без петель.
+func (s *Solver) Walk(mz maze.Maze, start, end cells.Coordinates) Walk {
+	// Суть правила руки:
+	//
+	// Агент знает лишь переходы текущей клетки и своё направление. На каждом шаге он пробует повернуть
+	// в сторону руки, затем идти прямо, затем повернуть в противоположную сторону и, наконец, развернуться.
+	// В идеальном лабиринте так обходится вся компонента связности, и конец обязательно находится.
+	// В лабиринте с циклами агент может ходить вокруг "острова"; повторение положения (клетки и направления)
+	// означает, что конец недостижим этим правилом.
+	clear(s.seen)
+
+	current := state{coords: start}
+	trajectory := []cells.Coordinates{start}
+
+	for current.coords != end {
+		if _, ok := s.seen[current]; ok { // Агент зациклился.
+			return Walk{Trajectory: trajectory, Path: []cells.Coordinates{}}
+		}
+
+		s.seen[current] = struct{}{}
+
+		next, ok := s.step(mz.Cells[current.coords], current)
+		if !ok { // Из клетки нет ни одного перехода.
+			return Walk{Trajectory: trajectory, Path: []cells.Coordinates{}}
+		}
+
+		current = next
+		trajectory = append(trajectory, current.coords)
+	}
+
+	return Walk{Trajectory: trajectory, Path: sutils.EraseLoops(trajectory)}
+}
+
+// step возвращает следующее положение агента согласно правилу руки.
+func (s *Solver) step(cell *cells.Cell, current state) (state, bool) {
+	turns := []int{3, 0, 1, 2} // Налево, прямо, направо, назад (в четвертях оборота по часовой стрелке).
+	if s.hand == Right {
+		turns = []int{1, 0, 3, 2} // Направо, прямо, налево, назад.
+	}
+
+	for _, turn := range turns {
+		heading := (current.heading + turn) % len(headingDx)
+		next := cells.Coordinates{
+			X: current.coords.X + headingDx[heading],
+			Y: current.coords.Y + headingDy[heading],
+		}
+
+		for _, transition := range cell.Transitions {
+			if transition == next {
+				return state{coords: next, heading: heading}, true
+			}
+		}
+	}
+
+	return state{}, false
+}
+
+// countDistinct возвращает количество различных клеток траектории.
+func countDistinct(trajectory []cells.Coordinates) int {
+	distinct := make(map[cells.Coordinates]struct{}, len(trajectory))
+
+	for _, coords := range trajectory {
+		distinct[coords] = struct{}{}
+	}
+
+	return len(distinct)
+}
@@ -0,0 +1,78 @@
+package wallfollower_test
+
+import (
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/prim"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/bfs"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/wallfollower"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWallFollowerSolverWalkOnPerfectMaze(t *testing.T) {
+	mz, err := prim.NewGenerator().Generate(24, 24)
+	require.NoError(t, err)
+
+	start, end := cells.Coordinates{X: 0, Y: 0}, cells.Coordinates{X: 23, Y: 23}
+
+	// В идеальном лабиринте путь без петель единственен и совпадает с найденным поиском в ширину.
+	expected, err := bfs.NewSolver(sutils.DefaultCosts()).Solve(mz, start, end)
+	require.NoError(t, err)
+
+	for _, hand := range []wallfollower.Hand{wallfollower.Left, wallfollower.Right} {
+		walk := wallfollower.NewSolver(sutils.DefaultCosts(), hand).Walk(mz, start, end)
+
+		assert.Equal(t, expected.Path, walk.Path)
+		assert.Equal(t, start, walk.Trajectory[0])
+		assert.Equal(t, end, walk.Trajectory[len(walk.Trajectory)-1])
+		assert.GreaterOrEqual(t, len(walk.Trajectory), len(walk.Path))
+		assert.True(t, isTrajectoryValid(mz, walk.Trajectory))
+	}
+}
+
+func TestWallFollowerSolverWalkAroundIsland(t *testing.T) {
+	// Кольцо из восьми клеток вокруг недостижимого центра: агент обходит кольцо и зацикливается.
+	mz := maze.New(3, 3)
+	ring := []cells.Coordinates{
+		{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}, {X: 2, Y: 1},
+		{X: 2, Y: 2}, {X: 1, Y: 2}, {X: 0, Y: 2}, {X: 0, Y: 1},
+	}
+
+	for i, coords := range ring {
+		next := ring[(i+1)%len(ring)]
+
+		mz.Cells[coords].Type = cells.Pass
+		mz.Cells[coords].Transitions = append(mz.Cells[coords].Transitions, next)
+		mz.Cells[next].Transitions = append(mz.Cells[next].Transitions, coords)
+	}
+
+	for _, hand := range []wallfollower.Hand{wallfollower.Left, wallfollower.Right} {
+		walk := wallfollower.NewSolver(sutils.DefaultCosts(), hand).Walk(mz, ring[0], cells.Coordinates{X: 1, Y: 1})
+
+		assert.Empty(t, walk.Path)
+		assert.GreaterOrEqual(t, len(walk.Trajectory), len(ring))
+	}
+}
+
+// isTrajectoryValid проверяет, что между каждой парой последовательных клеток траектории есть переход.
+func isTrajectoryValid(mz maze.Maze, trajectory []cells.Coordinates) bool {
+	for i := 1; i < len(trajectory); i++ {
+		linked := false
+
+		for _, next := range mz.Cells[trajectory[i-1]].Transitions {
+			if next == trajectory[i] {
+				linked = true
+			}
+		}
+
+		if !linked {
+			return false
+		}
+	}
+
+	return true
+}
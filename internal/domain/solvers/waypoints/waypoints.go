@@ -0,0 +1,264 @@
+package waypoints
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/dijkstra"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils"
+)
+
+// ExactLimit - наибольшее количество промежуточных точек, для которого порядок обхода подбирается точно.
+const ExactLimit = 12
+
+// Solver - структура решателя, ищущего самый дешёвый маршрут через промежуточные точки.
+type Solver struct {
+	costs        sutils.CostModel                    // Модель стоимости путей.
+	ordered      bool                                // Если true, точки обходятся в заданном порядке.
+	dijkstra     *dijkstra.Solver                    // Решатель, вычисляющий оценки путей от каждой точки.
+	points       []cells.Coordinates                 // Начало, промежуточные точки и конец.
+	dist         []map[cells.Coordinates]sutils.Cost // Оценки путей от каждой точки, кроме конца.
+	predecessors []sutils.Predecessors               // Предшественники для восстановления путей от каждой точки, кроме конца.
+	explored     int                                 // Суммарное количество достигнутых вершин во всех запусках.
+	mz           maze.Maze
+}
+
+// NewSolver возвращает указатель на инициализированный Solver со стоимостью путей в модели costs;
+// при ordered = true точки обходятся в заданном порядке.
+func NewSolver(costs sutils.CostModel, ordered bool) *Solver {
+	return &Solver{
+		costs:    costs,
+		ordered:  ordered,
+		dijkstra: dijkstra.NewSolver(costs),
+	}
+}
+
+// Solve находит путь от start до end в mz без промежуточных точек и возвращает его вместе со статистикой поиска.
+func (s *Solver) Solve(mz maze.Maze, start, end cells.Coordinates) (sutils.Result, error) {
+	return s.SolveWaypoints(mz, start, end, nil)
+}
+
+// SolveWaypoints находит самый дешёвый путь от start до end в mz, проходящий через все waypoints,
+// и возвращает его вместе со статистикой поиска.
+func (s *Solver) SolveWaypoints(mz maze.Maze, start, end cells.Coordinates, waypoints []cells.Coordinates) (
+	sutils.Result, error,
+) {
+	for _, coords := range append([]cells.Coordinates{start, end}, waypoints...) {
+		err := sutils.Validate(mz, coords, coords)
+		if err != nil {
+			return sutils.Result{}, err
+		}
+	}
+
+	begin := time.Now()
+
+	s.prepare(mz, start, end, waypoints)
+
+	err := s.computeDistances()
+	if err != nil {
+		return sutils.Result{}, err
+	}
+
+	return sutils.NewResult(s.costs, mz, s.restorePath(s.chooseOrder()), s.explored, time.Since(begin))
+}
+
+// computeDistances вычисляет оценки путей от каждой точки, кроме конца, и проверяет, что все точки достижимы.
+func (s *Solver) computeDistances() error {
+	for i := range len(s.points) - 1 {
+		dist, predecessors := s.dijkstra.Distances(s.mz, s.points[i])
+
+		s.dist = append(s.dist, dist)
+		s.predecessors = append(s.predecessors, predecessors)
+
+		for _, d := range dist {
+			if d != dijkstra.INF {
+				s.explored++
+			}
+		}
+	}
+
+	// Переходы двусторонние, поэтому точки, достижимые из начала, достижимы и друг из друга.
+	for _, coords := range s.points[1:] {
+		if s.dist[0][coords] == dijkstra.INF {
+			return fmt.Errorf("point %d:%d: %w", coords.X, coords.Y, sutils.ErrUnreachable)
+		}
+	}
+
+	return nil
+}
+
+// chooseOrder возвращает порядок обхода промежуточных точек в виде их номеров в points.
+func (s *Solver) chooseOrder() []int {
+	order := make([]int, 0, len(s.points)-2)
+	for i := 1; i < len(s.points)-1; i++ {
+		order = append(order, i)
+	}
+
+	switch {
+	case s.ordered:
+		return order
+	case len(order) <= ExactLimit:
+		return s.exactOrder()
+	default:
+		return s.improveOrder(s.nearestOrder())
+	}
+}
+
+// exactOrder подбирает оптимальный порядок обхода динамическим программированием по подмножествам (Хелд - Карп).
+func (s *Solver) exactOrder() []int {
+	// dp[mask][j] - наименьшая стоимость маршрута из начала через точки подмножества mask, заканчивающегося в точке j;
+	// parent[mask][j] - точка, предшествующая j в этом маршруте (-1 для начала).
+	k := len(s.points) - 2
+	if k == 0 {
+		return []int{}
+	}
+
+	full := 1<<k - 1
+	dp := make([][]sutils.Cost, full+1)
+	parent := make([][]int, full+1)
+
+	for mask := range dp {
+		dp[mask] = make([]sutils.Cost, k)
+		parent[mask] = make([]int, k)
+
+		for j := range k {
+			dp[mask][j], parent[mask][j] = dijkstra.INF, -1
+		}
+	}
+
+	for j := range k {
+		dp[1<<j][j] = s.cost(0, j+1)
+	}
+
+	for mask := 1; mask <= full; mask++ {
+		for j := range k {
+			if mask&(1<<j) == 0 || dp[mask][j] == dijkstra.INF {
+				continue
+			}
+
+			for next := range k {
+				if mask&(1<<next) != 0 {
+					continue
+				}
+
+				nextMask := mask | 1<<next
+
+				if newCost := dp[mask][j] + s.cost(j+1, next+1); newCost < dp[nextMask][next] {
+					dp[nextMask][next] = newCost
+					parent[nextMask][next] = j
+				}
+			}
+		}
+	}
+
+	last, best := 0, sutils.Cost(dijkstra.INF)
+
+	for j := range k {
+		if total := dp[full][j] + s.cost(j+1, k+1); total < best {
+			last, best = j, total
+		}
+	}
+
+	order := make([]int, 0, k)
+
+	for mask := full; last != -1; {
+		order = append(order, last+1)
+		mask, last = mask&^(1<<last), parent[mask][last]
+	}
+
+	slices.Reverse(order)
+
+	return order
+}
+
+// nearestOrder строит порядок обхода жадно, каждый раз переходя в самую дешёвую из непосещённых точек.
+func (s *Solver) nearestOrder() []int {
+	k := len(s.points) - 2
+	order := make([]int, 0, k)
+	visited := make([]bool, k+1)
+
+	for current := 0; len(order) < k; {
+		next := -1
+
+		for candidate := 1; candidate <= k; candidate++ {
+			if !visited[candidate] && (next == -1 || s.cost(current, candidate) < s.cost(current, next)) {
+				next = candidate
+			}
+		}
+
+		visited[next] = true
+		order = append(order, next)
+		current = next
+	}
+
+	return order
+}
+
+// improveOrder улучшает порядок обхода разворотами отрезков (2-opt), пока это уменьшает стоимость маршрута.
+func (s *Solver) improveOrder(order []int) []int {
+	best := s.orderCost(order)
+
+	for improved := true; improved; {
+		improved = false
+
+		for i := 0; i < len(order)-1; i++ {
+			for j := i + 1; j < len(order); j++ {
+				slices.Reverse(order[i : j+1])
+
+				// Стоимость перехода зависит от направления, поэтому маршрут пересчитывается целиком.
+				if newCost := s.orderCost(order); newCost < best {
+					best, improved = newCost, true
+				} else {
+					slices.Reverse(order[i : j+1])
+				}
+			}
+		}
+	}
+
+	return order
+}
+
+// orderCost возвращает стоимость маршрута от начала через точки order до конца без учёта веса начала.
+func (s *Solver) orderCost(order []int) sutils.Cost {
+	var total sutils.Cost
+
+	previous := 0
+
+	for _, current := range append(order, len(s.points)-1) {
+		total += s.cost(previous, current)
+		previous = current
+	}
+
+	return total
+}
+
+// cost возвращает стоимость пути от точки i до точки j без учёта стоимости входа в точку i.
+func (s *Solver) cost(i, j int) sutils.Cost {
+	return s.dist[i][s.points[j]] - s.costs.Start(s.mz, s.points[i])
+}
+
+// restorePath склеивает путь из кратчайших путей между последовательными точками маршрута.
+func (s *Solver) restorePath(order []int) []cells.Coordinates {
+	path := []cells.Coordinates{s.points[0]}
+	previous := 0
+
+	for _, current := range append(order, len(s.points)-1) {
+		segment := sutils.RestorePath(s.points[previous], s.points[current], s.predecessors[previous])
+		path = append(path, segment[1:]...) // Первая клетка отрезка уже является последней клеткой пути.
+		previous = current
+	}
+
+	return path
+}
+
+// prepare подготавливает Solver для исполнения SolveWaypoints.
+func (s *Solver) prepare(mz maze.Maze, start, end cells.Coordinates, waypoints []cells.Coordinates) {
+	s.mz = mz
+	s.points = append(append([]cells.Coordinates{start}, waypoints...), end)
+	s.dist = s.dist[:0]
+	s.predecessors = s.predecessors[:0]
+	s.explored = 0
+}
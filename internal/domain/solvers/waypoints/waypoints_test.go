@@ -0,0 +1,242 @@
+package waypoints_test
+
+import (
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/generators/cave"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/dijkstra"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/waypoints"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// passCost - стоимость обычного прохода в модели стоимости по умолчанию.
+var passCost = sutils.DefaultTypes()[cells.Pass]
+
+func TestWaypointsSolverSolveWaypoints(t *testing.T) {
+	corridor := newCorridorMaze(7)
+
+	// Клетка {X: 3, Y: 0} отрезана от остального коридора.
+	broken := newCorridorMaze(7)
+	broken.Cells[cells.Coordinates{X: 3, Y: 0}].Transitions = nil
+	broken.Cells[cells.Coordinates{X: 2, Y: 0}].Transitions = []cells.Coordinates{{X: 1, Y: 0}}
+	broken.Cells[cells.Coordinates{X: 4, Y: 0}].Transitions = []cells.Coordinates{{X: 5, Y: 0}}
+
+	type args struct {
+		mz        maze.Maze
+		start     cells.Coordinates
+		end       cells.Coordinates
+		waypoints []cells.Coordinates
+		ordered   bool
+	}
+
+	tests := []struct {
+		name         string
+		args         args
+		expectedCost sutils.Cost
+		expectedErr  error
+	}{
+		{
+			name: "without waypoints",
+			args: args{
+				mz:    corridor,
+				start: cells.Coordinates{X: 0, Y: 0},
+				end:   cells.Coordinates{X: 6, Y: 0},
+			},
+			expectedCost: 7 * passCost,
+		},
+		{
+			name: "waypoints are visited in the cheapest order",
+			args: args{
+				mz:        corridor,
+				start:     cells.Coordinates{X: 0, Y: 0},
+				end:       cells.Coordinates{X: 6, Y: 0},
+				waypoints: []cells.Coordinates{{X: 4, Y: 0}, {X: 2, Y: 0}},
+			},
+			expectedCost: 7 * passCost,
+		},
+		{
+			name: "waypoints are visited in the given order",
+			args: args{
+				mz:        corridor,
+				start:     cells.Coordinates{X: 0, Y: 0},
+				end:       cells.Coordinates{X: 6, Y: 0},
+				waypoints: []cells.Coordinates{{X: 4, Y: 0}, {X: 2, Y: 0}},
+				ordered:   true,
+			},
+			expectedCost: 11 * passCost,
+		},
+		{
+			name: "waypoint is unreachable",
+			args: args{
+				mz:        broken,
+				start:     cells.Coordinates{X: 0, Y: 0},
+				end:       cells.Coordinates{X: 2, Y: 0},
+				waypoints: []cells.Coordinates{{X: 3, Y: 0}},
+			},
+			expectedErr: sutils.ErrUnreachable,
+		},
+		{
+			name: "waypoint is out of bounds",
+			args: args{
+				mz:        corridor,
+				start:     cells.Coordinates{X: 0, Y: 0},
+				end:       cells.Coordinates{X: 6, Y: 0},
+				waypoints: []cells.Coordinates{{X: 7, Y: 0}},
+			},
+			expectedErr: sutils.ErrOutOfBounds,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := waypoints.NewSolver(sutils.DefaultCosts(), tt.args.ordered)
+
+			result, err := s.SolveWaypoints(tt.args.mz, tt.args.start, tt.args.end, tt.args.waypoints)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCost, result.Cost)
+			assert.True(t, isRouteValid(tt.args.mz, result.Path, tt.args.start, tt.args.end, tt.args.waypoints))
+		})
+	}
+}
+
+func TestWaypointsSolverSolveWaypointsOnCave(t *testing.T) {
+	mz, err := cave.NewGenerator().Generate(32, 32)
+	require.NoError(t, err)
+
+	var passages []cells.Coordinates
+
+	for coords, cell := range mz.Cells {
+		if cell.Type != cells.Wall {
+			passages = append(passages, coords)
+		}
+	}
+
+	step := len(passages) / 20
+	start, end := passages[0], passages[len(passages)-1]
+
+	t.Run("exact order is optimal", func(t *testing.T) {
+		points := []cells.Coordinates{passages[step], passages[2*step], passages[3*step], passages[4*step], passages[5*step]}
+
+		result, err := waypoints.NewSolver(sutils.DefaultCosts(), false).SolveWaypoints(mz, start, end, points)
+		require.NoError(t, err)
+
+		assert.Equal(t, bruteForceCost(t, mz, start, end, points), result.Cost)
+		assert.True(t, isRouteValid(mz, result.Path, start, end, points))
+	})
+
+	t.Run("heuristic order visits every waypoint", func(t *testing.T) {
+		var points []cells.Coordinates
+		for i := 1; i <= waypoints.ExactLimit+3; i++ {
+			points = append(points, passages[i*step])
+		}
+
+		result, err := waypoints.NewSolver(sutils.DefaultCosts(), false).SolveWaypoints(mz, start, end, points)
+		require.NoError(t, err)
+
+		ordered, err := waypoints.NewSolver(sutils.DefaultCosts(), true).SolveWaypoints(mz, start, end, points)
+		require.NoError(t, err)
+
+		assert.LessOrEqual(t, result.Cost, ordered.Cost) // 2-opt не ухудшает начальный порядок.
+		assert.True(t, isRouteValid(mz, result.Path, start, end, points))
+	})
+}
+
+// bruteForceCost перебирает все порядки обхода points и возвращает наименьшую стоимость маршрута.
+func bruteForceCost(t *testing.T, mz maze.Maze, start, end cells.Coordinates, points []cells.Coordinates) sutils.Cost {
+	t.Helper()
+
+	costs := sutils.DefaultCosts()
+	s := dijkstra.NewSolver(costs)
+	best := sutils.Cost(dijkstra.INF)
+
+	var permute func(k int)
+
+	permute = func(k int) {
+		if k == len(points) {
+			route := append(append([]cells.Coordinates{start}, points...), end)
+			total := costs.Start(mz, start)
+
+			for i := 1; i < len(route); i++ {
+				result, err := s.Solve(mz, route[i-1], route[i])
+				require.NoError(t, err)
+
+				total += result.Cost - costs.Start(mz, route[i-1])
+			}
+
+			best = min(best, total)
+
+			return
+		}
+
+		for i := k; i < len(points); i++ {
+			points[k], points[i] = points[i], points[k]
+			permute(k + 1)
+			points[k], points[i] = points[i], points[k]
+		}
+	}
+
+	permute(0)
+
+	return best
+}
+
+// isRouteValid проверяет, что path - связный путь от start до end, проходящий через все waypoints.
+func isRouteValid(mz maze.Maze, path []cells.Coordinates, start, end cells.Coordinates, waypoints []cells.Coordinates) bool {
+	if len(path) == 0 || path[0] != start || path[len(path)-1] != end {
+		return false
+	}
+
+	visited := map[cells.Coordinates]struct{}{start: {}}
+
+	for i := 1; i < len(path); i++ {
+		linked := false
+
+		for _, next := range mz.Cells[path[i-1]].Transitions {
+			if next == path[i] {
+				linked = true
+			}
+		}
+
+		if !linked {
+			return false
+		}
+
+		visited[path[i]] = struct{}{}
+	}
+
+	for _, coords := range waypoints {
+		if _, ok := visited[coords]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// newCorridorMaze возвращает лабиринт-коридор из length обычных проходов.
+func newCorridorMaze(length int) maze.Maze {
+	mz := maze.New(1, length)
+
+	for x := range length {
+		coords := cells.Coordinates{X: x, Y: 0}
+		mz.Cells[coords].Type = cells.Pass
+
+		if x > 0 {
+			previous := cells.Coordinates{X: x - 1, Y: 0}
+			mz.Cells[previous].Transitions = append(mz.Cells[previous].Transitions, coords)
+			mz.Cells[coords].Transitions = append(mz.Cells[coords].Transitions, previous)
+		}
+	}
+
+	return mz
+}
@@ -1,9 +1,52 @@
 package config
 
-// Config содержит строковое обозначение типов Generator, Solver, UI и Renderer.
+import "github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+
+// Config содержит строковое обозначение типов Generator, Solver, UI и Renderer, признак вывода внешней стены,
+// раскладку регионов составного генератора, границы длины решения генерируемых лабиринтов,
+// признаки анимации поиска пути и отображения тепловой карты, модель стоимости путей,
+// способ расстановки начала и конца и наименьшее расстояние между ними.
 type Config struct {
-	GeneratorType string `json:"GeneratorType"`
-	SolverType    string `json:"SolverType"`
-	UIType        string `json:"UIType"`
-	RendererType  string `json:"RendererType"`
+	GeneratorType   string     `json:"GeneratorType"`
+	SolverType      string     `json:"SolverType"`
+	UIType          string     `json:"UIType"`
+	RendererType    string     `json:"RendererType"`
+	OuterWall       bool       `json:"OuterWall"`
+	CompositeLayout [][]string `json:"CompositeLayout"`
+	SolutionBounds  *Bounds    `json:"SolutionBounds"`
+	Animate         bool       `json:"Animate"`
+	Heatmap         bool       `json:"Heatmap"`
+	CostModel       CostModel  `json:"CostModel"`
+	Placement       string     `json:"Placement"`
+	MinDistance     int        `json:"MinDistance"`
+}
+
+// CostModel содержит стоимости входа в клетки по названиям их типов, признак оплаты входа в начальную клетку
+// и надбавки за отдельные переходы. Не заданные стоимости типов берутся из модели по умолчанию,
+// а вход в начальную клетку по умолчанию оплачивается.
+type CostModel struct {
+	Types       map[string]int `json:"Types"`
+	ChargeStart *bool          `json:"ChargeStart"`
+	Edges       []EdgeCost     `json:"Edges"`
+}
+
+// IsStartCharged возвращает true, если вход в начальную клетку оплачивается, иначе false.
+func (c CostModel) IsStartCharged() bool {
+	return c.ChargeStart == nil || *c.ChargeStart
+}
+
+// EdgeCost содержит надбавку за переход из клетки From в соседнюю клетку To.
+type EdgeCost struct {
+	From cells.Coordinates `json:"From"`
+	To   cells.Coordinates `json:"To"`
+	Cost int               `json:"Cost"`
+}
+
+// Bounds содержит концы пути и границы количества клеток кратчайшего пути между ними,
+// в которые должен укладываться генерируемый лабиринт. Если раздел не задан, длина решения не ограничивается.
+type Bounds struct {
+	Start     cells.Coordinates `json:"Start"`
+	End       cells.Coordinates `json:"End"`
+	MinLength int               `json:"MinLength"`
+	MaxLength int               `json:"MaxLength"`
 }
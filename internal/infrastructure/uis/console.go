@@ -2,11 +2,16 @@ package uis
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
+	"math/big"
 	"os"
+	"time"
 
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/tremaux"
 )
 
 const (
@@ -15,7 +20,19 @@ const (
 	NoteMessage                  = "Примечание: начало координат лежит в левом верхнем углу, координаты начинаются с нуля"
 	StartInputMessage            = "Введите координаты начальной точки:"
 	EndInputMessage              = "Введите координаты конечной точки:"
+	WaypointsCountInputMessage   = "Введите количество промежуточных точек (0, если их нет):"
+	ErrorWaypointsCountMessage   = "Пожалуйста, введите корректное количество промежуточных точек:"
+	WaypointInputMessageFormat   = "Введите координаты промежуточной точки №%d:"
 	ErrorCoordinatesInputMessage = "Пожалуйста, введите корректные координаты:"
+	ResultMessageFormat          = "Длина пути: %d\nСтоимость пути: %d\nИсследовано клеток: %d\nВремя поиска: %v\n"
+	UnreachableMessage           = "Путь от начальной до конечной точки не существует."
+	OutOfBoundsMessage           = "Начальная, конечная или промежуточная точка лежит за пределами лабиринта."
+	MaskedCellMessage            = "Начальная, конечная или промежуточная точка находится в стене."
+	SolveErrorMessageFormat      = "Не удалось найти путь: %v\n"
+	AlternativeMessageFormat     = "Путь №%d: длина %d, стоимость %d\n"
+	OptimalCountMessageFormat    = "Равноценных кратчайших путей: %s\n"
+	ClearScreen                  = "\033[H\033[2J" // ANSI-последовательность, очищающая терминал перед кадром.
+	FrameDelay                   = 50 * time.Millisecond
 )
 
 type reader interface {
@@ -73,8 +90,10 @@ func (c *console) AskMazeDimensions() (height, width int) {
 	return height, width
 }
 
-// AskCoordinates cпрашивает координаты start и end.
-func (c *console) AskCoordinates(height, width int) (start, end cells.Coordinates) {
+// AskCoordinates cпрашивает координаты start, end и, если withWaypoints = true, промежуточных точек waypoints.
+func (c *console) AskCoordinates(height, width int, withWaypoints bool) (
+	start, end cells.Coordinates, waypoints []cells.Coordinates,
+) {
 	var x, y int
 
 	areValid := func(data ...any) bool {
@@ -124,7 +143,54 @@ func (c *console) AskCoordinates(height, width int) (start, end cells.Coordinate
 		Y: y,
 	}
 
-	return start, end
+	if !withWaypoints {
+		return start, end, nil
+	}
+
+	return start, end, c.askWaypoints(areValid)
+}
+
+// askWaypoints спрашивает количество промежуточных точек и их координаты, проверяя координаты с помощью areValid.
+func (c *console) askWaypoints(areValid func(data ...any) bool) []cells.Coordinates {
+	var count, x, y int
+
+	isCountValid := func(data ...any) bool {
+		if len(data) != 1 {
+			return false
+		}
+
+		number, ok := data[0].(*int)
+
+		return ok && *number >= 0
+	}
+
+	AskCorrectData(
+		c.printf,
+		c.read,
+		isCountValid,
+		"\n%s\n",
+		WaypointsCountInputMessage,
+		ErrorWaypointsCountMessage,
+		&count,
+	)
+
+	waypoints := make([]cells.Coordinates, 0, count)
+
+	for i := range count {
+		AskCorrectData(
+			c.printf,
+			c.read,
+			areValid,
+			"\n%s\n",
+			fmt.Sprintf(WaypointInputMessageFormat, i+1),
+			ErrorCoordinatesInputMessage,
+			&x, &y,
+		)
+
+		waypoints = append(waypoints, cells.Coordinates{X: x, Y: y})
+	}
+
+	return waypoints
 }
 
 // DisplayMaze отображает лабиринт.
@@ -137,6 +203,68 @@ func (c *console) DisplayMazeWithPath(mz maze.Maze, path []cells.Coordinates) {
 	c.printf("\n%s\n", c.renderer.RenderPath(mz, path))
 }
 
+// DisplayFilled отображает лабиринт, заполненные клетки filled и оставшийся путь path.
+func (c *console) DisplayFilled(mz maze.Maze, path []cells.Coordinates, filled map[cells.Coordinates]struct{}) {
+	c.printf("\n%s\n", c.renderer.RenderFilled(mz, path, filled))
+}
+
+// DisplayMarks отображает лабиринт, путь path и проходы, помеченные алгоритмом Тремо один и два раза.
+func (c *console) DisplayMarks(mz maze.Maze, path []cells.Coordinates, marks map[tremaux.Passage]int) {
+	c.printf("\n%s\n", c.renderer.RenderMarks(mz, path, marks))
+}
+
+// DisplayResult отображает статистику поиска пути.
+func (c *console) DisplayResult(result sutils.Result) {
+	c.printf(ResultMessageFormat, len(result.Path), result.Cost, result.Explored, result.Duration)
+}
+
+// DisplaySolveError сообщает, почему путь не найден.
+func (c *console) DisplaySolveError(err error) {
+	switch {
+	case errors.Is(err, sutils.ErrUnreachable):
+		c.printf("%s\n", UnreachableMessage)
+	case errors.Is(err, sutils.ErrOutOfBounds):
+		c.printf("%s\n", OutOfBoundsMessage)
+	case errors.Is(err, sutils.ErrMaskedCell):
+		c.printf("%s\n", MaskedCellMessage)
+	default:
+		c.printf(SolveErrorMessageFormat, err)
+	}
+}
+
+// DisplayTrace анимирует поиск пути: кадр отрисовывается после каждого раскрытия вершины и в конце поиска.
+func (c *console) DisplayTrace(mz maze.Maze, events []sutils.Event) {
+	for i, event := range events {
+		if event.Kind == sutils.Popped {
+			c.printf("%s%s\n", ClearScreen, c.renderer.RenderTrace(mz, events[:i+1]))
+			time.Sleep(FrameDelay)
+		}
+	}
+
+	c.printf("%s%s\n", ClearScreen, c.renderer.RenderTrace(mz, events))
+}
+
+// DisplayAlternatives отображает лабиринт с альтернативными путями и количество равноценных кратчайших путей.
+func (c *console) DisplayAlternatives(mz maze.Maze, alternatives []sutils.Result, optimalCount *big.Int) {
+	paths := make([][]cells.Coordinates, 0, len(alternatives))
+	for _, alternative := range alternatives {
+		paths = append(paths, alternative.Path)
+	}
+
+	c.printf("\n%s\n", c.renderer.RenderPaths(mz, paths))
+
+	for i, alternative := range alternatives {
+		c.printf(AlternativeMessageFormat, i+1, len(alternative.Path), alternative.Cost)
+	}
+
+	c.printf(OptimalCountMessageFormat, optimalCount)
+}
+
+// DisplayHeatmap отображает тепловую карту расстояний.
+func (c *console) DisplayHeatmap(mz maze.Maze, distances map[cells.Coordinates]sutils.Cost) {
+	c.printf("\n%s\n", c.renderer.RenderHeatmap(mz, distances))
+}
+
 // AskCorrectData спрашивает данные до тех пор, пока они не будут корректными, читая их в data...;
 // данные, которые нужно спросить, должны передаваться по указателю.
 func AskCorrectData(
@@ -1,11 +1,18 @@
 package uis_test
 
 import (
+	"bytes"
 	"errors"
+	"strings"
 	"testing"
 
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils/sutilstest"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/waypoints"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/uis"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAskCorrectData(t *testing.T) {
@@ -132,3 +139,84 @@ func TestAskCorrectData(t *testing.T) {
 		})
 	}
 }
+
+func TestConsoleAskCoordinates(t *testing.T) {
+	start, end := cells.Coordinates{X: 0, Y: 0}, cells.Coordinates{X: 3, Y: 2}
+
+	type args struct {
+		input         string
+		withWaypoints bool
+	}
+
+	tests := []struct {
+		name              string
+		args              args
+		expectedWaypoints []cells.Coordinates
+		expectedErrors    int
+	}{
+		{
+			name:              "without waypoints",
+			args:              args{input: "0 0\n3 2\n", withWaypoints: false},
+			expectedWaypoints: nil,
+		},
+		{
+			name:              "empty waypoint list",
+			args:              args{input: "0 0\n3 2\n0\n", withWaypoints: true},
+			expectedWaypoints: []cells.Coordinates{},
+		},
+		{
+			name:              "valid waypoints",
+			args:              args{input: "0 0\n3 2\n2\n1 1\n2 0\n", withWaypoints: true},
+			expectedWaypoints: []cells.Coordinates{{X: 1, Y: 1}, {X: 2, Y: 0}},
+		},
+		{
+			name:              "negative waypoint count is asked again",
+			args:              args{input: "0 0\n3 2\n-1\n1\n2 2\n", withWaypoints: true},
+			expectedWaypoints: []cells.Coordinates{{X: 2, Y: 2}},
+			expectedErrors:    1,
+		},
+		{
+			name:              "out-of-bounds waypoints are asked again",
+			args:              args{input: "0 0\n3 2\n1\n4 0\n1 -1\n0 3\n1 2\n", withWaypoints: true},
+			expectedWaypoints: []cells.Coordinates{{X: 1, Y: 2}},
+			expectedErrors:    3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var output bytes.Buffer
+
+			actualStart, actualEnd, actualWaypoints := uis.NewConsole(tt.args.input, &output).AskCoordinates(3, 4, tt.args.withWaypoints)
+
+			assert.Equal(t, start, actualStart)
+			assert.Equal(t, end, actualEnd)
+			assert.Equal(t, tt.expectedWaypoints, actualWaypoints)
+			assert.Equal(t, tt.args.withWaypoints, strings.Contains(output.String(), uis.WaypointsCountInputMessage))
+
+			errorsCount := strings.Count(output.String(), uis.ErrorCoordinatesInputMessage) +
+				strings.Count(output.String(), uis.ErrorWaypointsCountMessage)
+			assert.Equal(t, tt.expectedErrors, errorsCount)
+		})
+	}
+}
+
+func TestConsoleWaypointsOnWalls(t *testing.T) {
+	// Консоль знает лишь размеры лабиринта, поэтому точку в стене она принимает,
+	// а решатель отклоняет её, и пользователь видит причину.
+	var output bytes.Buffer
+
+	console := uis.NewConsole("0 0\n2 0\n1\n1 0\n", &output)
+
+	start, end, points := console.AskCoordinates(2, 3, true)
+	require.Equal(t, []cells.Coordinates{{X: 1, Y: 0}}, points)
+
+	mz := sutilstest.NewGridMaze(2, 3, cells.Coordinates{X: 1, Y: 0})
+
+	_, err := waypoints.NewSolver(sutils.DefaultCosts(), false).SolveWaypoints(mz, start, end, points)
+	require.ErrorIs(t, err, sutils.ErrMaskedCell)
+
+	console.DisplaySolveError(err)
+
+	assert.Contains(t, output.String(), uis.MaskedCellMessage)
+}
@@ -0,0 +1,15 @@
+package uis
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// NewConsole возвращает консоль, читающую ввод из input и пишущую вывод в output.
+func NewConsole(input string, output io.Writer) *console {
+	return &console{
+		reader: strings.NewReader(input),
+		writer: bufio.NewWriter(output),
+	}
+}
@@ -1,20 +1,43 @@
 package uis
 
 import (
+	"math/big"
+
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/maze/cells"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/sutils"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain/solvers/tremaux"
 )
 
 type renderer interface {
-	Render(mz maze.Maze) string                               // Отображает лабиринт в готовую для визуализации строку.
-	RenderPath(mz maze.Maze, path []cells.Coordinates) string // Отображает лабиринт и путь в готовую для визуализации строку.
+	Render(mz maze.Maze) string                                   // Отображает лабиринт в готовую для визуализации строку.
+	RenderPath(mz maze.Maze, path []cells.Coordinates) string     // Отображает лабиринт и путь в готовую для визуализации строку.
+	RenderTrace(mz maze.Maze, events []sutils.Event) string       // Отображает состояние поиска после событий events.
+	RenderPaths(mz maze.Maze, paths [][]cells.Coordinates) string // Отображает лабиринт и несколько путей разными цветами.
+	// Отображает лабиринт, окрашивая клетки градиентом по расстоянию до них.
+	RenderHeatmap(mz maze.Maze, distances map[cells.Coordinates]sutils.Cost) string
+	// Отображает лабиринт, заполненные клетки и путь в готовую для визуализации строку.
+	RenderFilled(mz maze.Maze, path []cells.Coordinates, filled map[cells.Coordinates]struct{}) string
+	// Отображает лабиринт, путь и проходы, помеченные алгоритмом Тремо, в готовую для визуализации строку.
+	RenderMarks(mz maze.Maze, path []cells.Coordinates, marks map[tremaux.Passage]int) string
 }
 
 type userInterface interface {
-	AskMazeDimensions() (height, width int)                          // Спрашивает ширину и высоту.
-	AskCoordinates(height, width int) (start, end cells.Coordinates) // Спрашивает координаты start и end.
-	DisplayMaze(mz maze.Maze)                                        // Отображает лабиринт.
-	DisplayMazeWithPath(mz maze.Maze, path []cells.Coordinates)      // Отображает лабиринт и путь на нём.
+	AskMazeDimensions() (height, width int) // Спрашивает ширину и высоту.
+	// Спрашивает координаты start, end и, если withWaypoints = true, промежуточных точек waypoints.
+	AskCoordinates(height, width int, withWaypoints bool) (start, end cells.Coordinates, waypoints []cells.Coordinates)
+	DisplayMaze(mz maze.Maze)                                   // Отображает лабиринт.
+	DisplayMazeWithPath(mz maze.Maze, path []cells.Coordinates) // Отображает лабиринт и путь на нём.
+	DisplayResult(result sutils.Result)                         // Отображает статистику поиска пути.
+	DisplaySolveError(err error)                                // Сообщает, почему путь не найден.
+	DisplayTrace(mz maze.Maze, events []sutils.Event)           // Анимирует поиск пути по его событиям.
+	// Отображает лабиринт с альтернативными путями и количество равноценных кратчайших путей.
+	DisplayAlternatives(mz maze.Maze, alternatives []sutils.Result, optimalCount *big.Int)
+	DisplayHeatmap(mz maze.Maze, distances map[cells.Coordinates]sutils.Cost) // Отображает тепловую карту расстояний.
+	// Отображает лабиринт, заполненные клетки filled и оставшийся путь path.
+	DisplayFilled(mz maze.Maze, path []cells.Coordinates, filled map[cells.Coordinates]struct{})
+	// Отображает лабиринт, путь path и проходы, помеченные алгоритмом Тремо один и два раза.
+	DisplayMarks(mz maze.Maze, path []cells.Coordinates, marks map[tremaux.Passage]int)
 }
 
 // New как фабрика возвращает конкретную реализацию userInterface по строке, обозначающей желаемую реализацию.